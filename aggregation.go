@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Aggregation modes, which combine the scores of the jurors into a contestant's result
+const (
+	aggregationWeightedMean = "weighted_mean" // Scores multiplied by the juror weights
+	aggregationTrimmedMean  = "trimmed_mean"  // Mean without the highest and lowest scores
+	aggregationMedian       = "median"        // Middle score
+	aggregationRankSum      = "rank_sum"      // Sum of the placings each juror gives, lower is better
+)
+
+// aggregationModes lists the modes with their display names, in menu order
+var aggregationModes = []struct {
+	Mode, Name string
+}{
+	{aggregationWeightedMean, "Weighted mean"},
+	{aggregationTrimmedMean, "Trimmed mean"},
+	{aggregationMedian, "Median"},
+	{aggregationRankSum, "Sum of ranks"},
+}
+
+// aggregationMode returns the aggregation mode of a competition. Competitions without one
+// use the weighted mean.
+func aggregationMode(comp Competition) string {
+	if comp.Aggregation == "" {
+		return aggregationWeightedMean
+	}
+	return comp.Aggregation
+}
+
+// aggregationName returns the display name of a mode
+func aggregationName(mode string) string {
+	for _, m := range aggregationModes {
+		if m.Mode == mode {
+			return m.Name
+		}
+	}
+	return mode
+}
+
+// trimCount returns the number of scores the trimmed mean drops at each end
+func trimCount(comp Competition) int {
+	if comp.TrimCount < 1 {
+		return 1
+	}
+	return comp.TrimCount
+}
+
+// activeJurors returns the jurors whose scores count. The trimmed mean, the median and the
+// sum of ranks count every juror equally; only jurors weighted with 0% are left out.
+func activeJurors(jurors []*Juror) []int {
+	var active []int
+	for j, juror := range jurors {
+		if juror.Weight > 0 {
+			active = append(active, j)
+		}
+	}
+	return active
+}
+
+// validateAggregation checks that the aggregation mode can be applied to the jury
+func validateAggregation(comp Competition) error {
+	mode := aggregationMode(comp)
+	known := false
+	for _, m := range aggregationModes {
+		known = known || m.Mode == mode
+	}
+	if !known {
+		return fmt.Errorf("Unknown aggregation mode '%s'.", comp.Aggregation)
+	}
+	if mode == aggregationWeightedMean {
+		return nil
+	}
+
+	// The other modes compare the jurors' totals, which only works if all jurors score the same
+	for _, juror := range comp.Jury {
+		if len(comp.Criteria) > 0 && len(juror.Criteria) > 0 {
+			return fmt.Errorf("%s requires every juror to score all criteria, but %s scores only %s.",
+				aggregationName(mode), juror.Name, strings.Join(juror.Criteria, ", "))
+		}
+	}
+	if mode == aggregationTrimmedMean {
+		k := trimCount(comp)
+		if active := len(activeJurors(comp.Jury)); active < 2*k+1 {
+			return fmt.Errorf("A trimmed mean dropping %d score(s) at each end needs at least %d jurors with a weight, but there are %d.", k, 2*k+1, active)
+		}
+	}
+	return nil
+}
+
+// trimmedMean returns the mean of the values without the k highest and k lowest. If fewer
+// values are left, as many are dropped as still leaves one.
+func trimmedMean(values []float64, k int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	if max := (len(sorted) - 1) / 2; k > max {
+		k = max
+	}
+	sum := 0.0
+	for _, value := range sorted[k : len(sorted)-k] {
+		sum += value
+	}
+	return sum / float64(len(sorted)-2*k)
+}
+
+// median returns the middle value, or the mean of the two middle values
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// averageRanks ranks the scores, highest first. Equal scores share the mean of the places
+// they occupy, like RANK.AVG in the spreadsheets. Missing scores are not ranked.
+func averageRanks(scores []*float64) []*float64 {
+	ranks := make([]*float64, len(scores))
+	for i, score := range scores {
+		if score == nil {
+			continue
+		}
+		higher, equal := 0, 0
+		for _, other := range scores {
+			switch {
+			case other == nil:
+			case *other > *score+scoreTolerance:
+				higher++
+			case sameScore(*other, *score):
+				equal++
+			}
+		}
+		rank := float64(higher) + float64(equal+1)/2
+		ranks[i] = &rank
+	}
+	return ranks
+}
+
+// aggregateScores combines the scores of the active jurors with the trimmed mean or the median
+func aggregateScores(mode string, k int, scores []*float64, active []int) float64 {
+	var values []float64
+	for _, j := range active {
+		if scores[j] != nil {
+			values = append(values, *scores[j])
+		}
+	}
+	if mode == aggregationTrimmedMean {
+		return trimmedMean(values, k)
+	}
+	return median(values)
+}
+
+// applyRankSums replaces the totals with the sum of the placings the active jurors gave
+func applyRankSums(results []*ContestantResult, active []int) {
+	for _, j := range active {
+		column := make([]*float64, len(results))
+		for i, result := range results {
+			column[i] = result.JurorTotals[j]
+		}
+		for i, rank := range averageRanks(column) {
+			if rank != nil {
+				results[i].Total += *rank
+			}
+		}
+	}
+}
+
+// aggregationFormula returns the overview formula that aggregates the juror scores in cells.
+// Cells of jurors who are left out must already be removed. Like the standings, the formula
+// only counts the jurors who scored: blank scores are ignored and the trimmed mean drops as
+// many scores as trimmedMean does for the number of scores present.
+func aggregationFormula(comp Competition, cells []string) string {
+	switch aggregationMode(comp) {
+	case aggregationTrimmedMean:
+		// TRIMMEAN drops the proportion of values rounded down to an even count; the extra
+		// half keeps 2k from being rounded down to 2k-2
+		count := fmt.Sprintf("COUNT(%s)", strings.Join(cells, "; "))
+		return fmt.Sprintf(`=IF(%s=0; ""; TRIMMEAN({%s}; (2*MIN(%d; INT((%s-1)/2))+0.5)/%s))`,
+			count, strings.Join(cells, ";"), trimCount(comp), count, count)
+	case aggregationMedian:
+		return fmt.Sprintf("=MEDIAN(%s)", strings.Join(cells, "; "))
+	case aggregationRankSum, aggregationWeightedMean:
+		// Weighted juror scores are already multiplied by their shares
+		return fmt.Sprintf("=SUM(%s)", strings.Join(cells, "; "))
+	}
+	return ""
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+)
+
+// sheetsTrimMean computes TRIMMEAN as the spreadsheets do: blanks are ignored and the number
+// of values dropped is the proportion of the count, rounded down to an even number
+func sheetsTrimMean(values []*float64, proportion float64) float64 {
+	var present []float64
+	for _, value := range values {
+		if value != nil {
+			present = append(present, *value)
+		}
+	}
+	sort.Float64s(present)
+	drop := int(math.Floor(float64(len(present)) * proportion))
+	drop -= drop % 2
+	kept := present[drop/2 : len(present)-drop/2]
+	sum := 0.0
+	for _, value := range kept {
+		sum += value
+	}
+	return sum / float64(len(kept))
+}
+
+// overviewScores computes what the Overview formulas of addAggregationFormulas and
+// aggregationFormula produce: each juror's score is the SUM of their "Total" cells, and the
+// result aggregates the scores of the jurors who scored. For the weighted mean with criteria,
+// each juror's score adds up their points times the weights of the 'Weights' sheet.
+func overviewScores(comp Competition, results *CompetitionResults) map[string]float64 {
+	tabs := contestantTabs(comp, results)
+	scores := make([][]*float64, len(tabs)) // Contestants by jurors
+	for i, tab := range tabs {
+		scores[i] = make([]*float64, len(comp.Jury))
+		for j := range comp.Jury {
+			contestant, _ := results.Scores(j, tab.Contestant)
+			sum, scored := 0.0, false
+			for _, line := range contestant.Rows {
+				if line.Total != nil {
+					sum += *line.Total
+					scored = true
+				}
+			}
+			if scored {
+				scores[i][j] = &sum
+			}
+		}
+	}
+
+	overview := make(map[string]float64)
+	matrix := effectiveJurorWeights(comp.Jury, comp.Criteria)
+	for i, tab := range tabs {
+		switch aggregationMode(comp) {
+		case aggregationWeightedMean:
+			for j := range comp.Jury {
+				contestant, _ := results.Scores(j, tab.Contestant)
+				var labels []string
+				var points []*float64
+				for _, line := range contestant.Rows {
+					labels = append(labels, line.Labels...)
+					points = append(points, line.Points...)
+				}
+				for c, p := range matchCriteria(labels, comp.Criteria) {
+					if matrix[j][c] > 0 && p >= 0 && points[p] != nil {
+						overview[tab.Contestant] += *points[p] * matrix[j][c] * float64(comp.Criteria[c].Weight) / 100
+					}
+				}
+			}
+		case aggregationTrimmedMean:
+			count := 0
+			for _, score := range scores[i] {
+				if score != nil {
+					count++
+				}
+			}
+			k := min(trimCount(comp), (count-1)/2)
+			overview[tab.Contestant] = sheetsTrimMean(scores[i], (float64(2*k)+0.5)/float64(count))
+		case aggregationMedian:
+			var present []float64
+			for _, score := range scores[i] {
+				if score != nil {
+					present = append(present, *score)
+				}
+			}
+			sort.Float64s(present)
+			if n := len(present); n%2 == 0 {
+				overview[tab.Contestant] = (present[n/2-1] + present[n/2]) / 2
+			} else {
+				overview[tab.Contestant] = present[n/2]
+			}
+		case aggregationRankSum:
+			// RANK.AVG of the juror's score among all contestants, highest first
+			for j := range comp.Jury {
+				if scores[i][j] == nil {
+					continue
+				}
+				higher, equal := 0, 0
+				for _, other := range scores {
+					if other[j] == nil {
+						continue
+					}
+					if *other[j] > *scores[i][j] {
+						higher++
+					} else if *other[j] == *scores[i][j] {
+						equal++
+					}
+				}
+				overview[tab.Contestant] += float64(higher) + float64(equal+1)/2
+			}
+		}
+	}
+	return overview
+}
+
+// testResults builds the results of a jury scoring two criteria in two "Points:" rows. Points
+// per contestant and juror are given for the criteria A and B; blank lists the contestant and
+// juror pairs whose tab is still empty.
+func testResults(contestants []string, points [][][2]float64, blank map[[2]int]bool) *CompetitionResults {
+	results := &CompetitionResults{}
+	for j := range points[0] {
+		scores := JurorScores{Juror: fmt.Sprintf("Juror %d", j+1)}
+		for i, contestant := range contestants {
+			tab := ContestantScores{Contestant: contestant, Sheet: contestant}
+			if !blank[[2]int{i, j}] {
+				a, b := points[i][j][0], points[i][j][1]
+				half := (a + b) / 2
+				tab.Rows = []ScoreLine{
+					{Labels: []string{"A", "B"}, Points: []*float64{&a, &b}, Total: &half},
+					{Labels: []string{"A", "B"}, Points: []*float64{&a, &b}, Total: &half},
+				}
+			}
+			scores.Contestants = append(scores.Contestants, tab)
+		}
+		results.Jurors = append(results.Jurors, scores)
+	}
+	return results
+}
+
+func TestStandingsMatchOverviewFormulas(t *testing.T) {
+	contestants := []string{"Clara", "David", "Eva"}
+	points := [][][2]float64{
+		{{8, 6}, {7, 9}, {9, 9}, {4, 5}, {8, 8}},
+		{{6, 6}, {9, 7}, {7, 5}, {8, 9}, {5, 6}},
+		{{9, 4}, {6, 6}, {8, 7}, {7, 7}, {9, 9}},
+	}
+	// David is not scored by the last two jurors yet, so their results use three scores
+	blank := map[[2]int]bool{{1, 3}: true, {1, 4}: true}
+
+	for _, mode := range []string{aggregationTrimmedMean, aggregationMedian, aggregationRankSum} {
+		t.Run(mode, func(t *testing.T) {
+			comp := Competition{
+				Aggregation: mode,
+				Criteria:    []*Criterion{{Name: "A", Weight: 70}, {Name: "B", Weight: 30}},
+			}
+			for j := 0; j < 5; j++ {
+				comp.Jury = append(comp.Jury, &Juror{Name: fmt.Sprintf("Juror %d", j+1), Weight: 20})
+			}
+			results := testResults(contestants, points, blank)
+
+			overview := overviewScores(comp, results)
+			standings := computeStandings(comp, results)
+			for _, result := range standings.Results {
+				if want := overview[result.Contestant]; !sameScore(result.Total, want) {
+					t.Errorf("%s: standings total = %.4f, overview formula = %.4f", result.Contestant, result.Total, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedOverviewFormulaMatchesStandings(t *testing.T) {
+	contestants := []string{"Clara", "David", "Eva"}
+	points := [][][2]float64{
+		{{8, 6}, {7, 9}, {9, 3}},
+		{{6, 6}, {9, 7}, {7, 10}},
+		{{9, 4}, {6, 6}, {8, 7}},
+	}
+	// Only the first two jurors score A and only the last two B, so the shares differ per criterion
+	comp := Competition{
+		Criteria: []*Criterion{{Name: "A", Weight: 70}, {Name: "B", Weight: 30}},
+		Jury: []*Juror{
+			{Name: "Juror 1", Weight: 50, Criteria: []string{"A"}},
+			{Name: "Juror 2", Weight: 30},
+			{Name: "Juror 3", Weight: 20, Criteria: []string{"B"}},
+		},
+	}
+	results := testResults(contestants, points, nil)
+
+	overview := overviewScores(comp, results)
+	standings := computeStandings(comp, results)
+	for _, result := range standings.Results {
+		if want := overview[result.Contestant]; !sameScore(result.Total, want) {
+			t.Errorf("%s: standings total = %.4f, overview formula = %.4f", result.Contestant, result.Total, want)
+		}
+	}
+
+	// Clara: A is 0.625*8 + 0.375*7, B is 0.6*9 + 0.4*3
+	if clara := overview["Clara"]; !sameScore(clara, 0.7*(0.625*8+0.375*7)+0.3*(0.6*9+0.4*3)) {
+		t.Errorf("Clara: overview formula = %.4f", clara)
+	}
+}
+
+func TestTrimmedMean(t *testing.T) {
+	tests := []struct {
+		values []float64
+		k      int
+		want   float64
+	}{
+		{[]float64{6, 9, 7, 1, 8}, 1, 7},
+		{[]float64{6, 9, 7, 1, 8, 10, 2}, 2, 7},
+		{[]float64{3, 5, 4, 10}, 1, 4.5},
+		{[]float64{4, 8, 6}, 2, 6}, // Too few values, only one is dropped at each end
+		{[]float64{5}, 1, 5},
+		{nil, 1, 0},
+	}
+	for _, test := range tests {
+		if got := trimmedMean(test.values, test.k); !sameScore(got, test.want) {
+			t.Errorf("trimmedMean(%v, %d) = %.4f, want %.4f", test.values, test.k, got, test.want)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{[]float64{7, 1, 9}, 7},
+		{[]float64{7, 1, 9, 4}, 5.5},
+		{[]float64{2}, 2},
+		{nil, 0},
+	}
+	for _, test := range tests {
+		if got := median(test.values); !sameScore(got, test.want) {
+			t.Errorf("median(%v) = %.4f, want %.4f", test.values, got, test.want)
+		}
+	}
+}
+
+func TestAverageRanks(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name   string
+		scores []*float64
+		want   []float64 // 0 where no rank is expected
+	}{
+		{"distinct", []*float64{value(7), value(9), value(8)}, []float64{3, 1, 2}},
+		// Like RANK.AVG: the tied scores occupy places 2 and 3 and share 2.5
+		{"pair tied", []*float64{value(9), value(8), value(8), value(5)}, []float64{1, 2.5, 2.5, 4}},
+		{"all tied", []*float64{value(6), value(6), value(6)}, []float64{2, 2, 2}},
+		{"within tolerance", []*float64{value(8), value(8.001), value(7)}, []float64{1.5, 1.5, 3}},
+		{"missing scores are not ranked", []*float64{value(4), nil, value(9)}, []float64{2, 0, 1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ranks := averageRanks(test.scores)
+			for i, rank := range ranks {
+				switch {
+				case test.want[i] == 0 && rank != nil:
+					t.Errorf("rank %d = %.2f, want none", i, *rank)
+				case test.want[i] != 0 && (rank == nil || *rank != test.want[i]):
+					t.Errorf("rank %d = %v, want %.2f", i, rank, test.want[i])
+				}
+			}
+		})
+	}
+}
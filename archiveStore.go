@@ -0,0 +1,407 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/s00500/env_logger"
+)
+
+const archiveCompetitionsDir = "competitions" // Folder inside the archive holding the competitions
+
+// archiveEntry is a file inside a competition archive
+type archiveEntry struct {
+	Data     []byte
+	Modified time.Time
+}
+
+// archiveStore keeps all competitions, their history and migration backups in a single
+// zip file, which is easy to copy between computers. The archive is rewritten atomically
+// on every change.
+type archiveStore struct {
+	file  string
+	mutex sync.Mutex
+}
+
+// newArchiveStore returns a store for the competitions in the archive file
+func newArchiveStore(file string) *archiveStore {
+	return &archiveStore{file: file}
+}
+
+func archiveCompetitionName(id string) string {
+	return path.Join(archiveCompetitionsDir, competitionFileName(id))
+}
+
+func archiveHistoryDir(id string) string {
+	return path.Join(historyDir, id) + "/"
+}
+
+// read returns all entries of the archive. A missing or empty file is an empty archive.
+func (s *archiveStore) read() (map[string]archiveEntry, error) {
+	entries := make(map[string]archiveEntry)
+	if info, err := os.Stat(s.file); os.IsNotExist(err) || (err == nil && info.Size() == 0) {
+		return entries, nil
+	}
+	reader, err := zip.OpenReader(s.file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if strings.HasSuffix(file.Name, "/") {
+			continue
+		}
+		contents, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", file.Name, err)
+		}
+		data, err := io.ReadAll(contents)
+		contents.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", file.Name, err)
+		}
+		entries[file.Name] = archiveEntry{Data: data, Modified: file.Modified}
+	}
+	return entries, nil
+}
+
+// write replaces the archive with the given entries
+func (s *archiveStore) write(entries map[string]archiveEntry) error {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, name := range names {
+		header := &zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: entries[name].Modified,
+		}
+		file, err := writer.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", name, err)
+		}
+		if _, err := file.Write(entries[name].Data); err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.file), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return writeFileAtomic(s.file, buffer.Bytes(), 0644)
+}
+
+// update reads the archive and lets change modify the entries. The result is written
+// back if change reports that it modified anything.
+func (s *archiveStore) update(change func(entries map[string]archiveEntry) (bool, error)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+	modified, err := change(entries)
+	if err != nil || !modified {
+		return err
+	}
+	return s.write(entries)
+}
+
+func (s *archiveStore) Location() string {
+	return s.file
+}
+
+func (s *archiveStore) List() ([]CompetitionInfo, error) {
+	s.mutex.Lock()
+	entries, err := s.read()
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := []CompetitionInfo{}
+	prefix := archiveCompetitionsDir + "/"
+	for name, entry := range entries {
+		if !strings.HasPrefix(name, prefix) || path.Ext(name) != ".json" || strings.Contains(strings.TrimPrefix(name, prefix), "/") {
+			continue
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".json")
+		infos = append(infos, CompetitionInfo{
+			ID:       id,
+			Name:     competitionName(entry.Data, id),
+			Modified: entry.Modified,
+		})
+	}
+	sortCompetitionInfos(infos)
+	return infos, nil
+}
+
+func (s *archiveStore) Load(id string) (Competition, error) {
+	var comp Competition
+	migrated := false
+	err := s.update(func(entries map[string]archiveEntry) (bool, error) {
+		name := archiveCompetitionName(id)
+		entry, exists := entries[name]
+		if !exists {
+			return false, fmt.Errorf("competition '%s' not found", id)
+		}
+
+		parsed, version, err := parseCompetition(entry.Data)
+		if err != nil {
+			return false, err
+		}
+		if !isValidCompetitionID(parsed.ID) {
+			return false, fmt.Errorf("the file has an invalid competition ID '%s'", parsed.ID)
+		}
+		comp = parsed
+		if version == currentSchemaVersion {
+			return false, nil
+		}
+
+		// Store the upgraded competition, keeping the original in the backups folder
+		now := time.Now()
+		backupName := path.Join(backupsDir, fmt.Sprintf("%s.v%d.%s.json", id, version, now.Format("20060102-150405")))
+		entries[backupName] = archiveEntry{Data: entry.Data, Modified: now}
+		data, err := encodeCompetition(comp)
+		if err != nil {
+			return false, err
+		}
+		delete(entries, name)
+		entries[archiveCompetitionName(comp.ID)] = archiveEntry{Data: data, Modified: now}
+		migrated = true
+		log.Printf("Migrated %s from schema version %d to %d, original saved as %s", name, version, currentSchemaVersion, backupName)
+		return true, nil
+	})
+	if err != nil {
+		if migrated {
+			return Competition{}, fmt.Errorf("failed to save migrated competition: %w", err)
+		}
+		return Competition{}, err
+	}
+	return comp, nil
+}
+
+func (s *archiveStore) Save(comp Competition) error {
+	if !isValidCompetitionID(comp.ID) {
+		return fmt.Errorf("invalid competition ID '%s'", comp.ID)
+	}
+	data, err := encodeCompetition(comp)
+	if err != nil {
+		return err
+	}
+
+	return s.update(func(entries map[string]archiveEntry) (bool, error) {
+		name := archiveCompetitionName(comp.ID)
+		if previous, exists := entries[name]; exists {
+			archiveEntryVersion(entries, comp.ID, previous)
+		}
+		entries[name] = archiveEntry{Data: data, Modified: time.Now()}
+		return true, nil
+	})
+}
+
+// archiveEntryVersion adds the previous state of a competition to its history inside the
+// archive, unless it is identical to the newest version kept there.
+func archiveEntryVersion(entries map[string]archiveEntry, id string, previous archiveEntry) {
+	versions := archiveHistory(entries, id)
+	if len(versions) > 0 && bytes.Equal(entries[archiveHistoryDir(id)+versions[0].File].Data, previous.Data) {
+		return
+	}
+
+	saved := previous.Modified.Local()
+	if saved.IsZero() {
+		saved = time.Now()
+	}
+	entries[archiveHistoryDir(id)+historyFileName(saved, func(file string) bool {
+		_, taken := entries[archiveHistoryDir(id)+file]
+		return taken
+	})] = previous
+
+	versions = archiveHistory(entries, id)
+	for _, version := range versions[min(len(versions), historyVersions):] {
+		delete(entries, archiveHistoryDir(id)+version.File)
+	}
+}
+
+// archiveHistory returns the versions kept for a competition inside the archive, newest first
+func archiveHistory(entries map[string]archiveEntry, id string) []HistoryVersion {
+	prefix := archiveHistoryDir(id)
+	versions := []HistoryVersion{}
+	for name := range entries {
+		file := strings.TrimPrefix(name, prefix)
+		if !strings.HasPrefix(name, prefix) || strings.Contains(file, "/") {
+			continue
+		}
+		if version, ok := parseHistoryFileName(file); ok {
+			versions = append(versions, version)
+		}
+	}
+	sortHistory(versions)
+	return versions
+}
+
+func (s *archiveStore) Delete(id string) error {
+	return s.update(func(entries map[string]archiveEntry) (bool, error) {
+		name := archiveCompetitionName(id)
+		if _, exists := entries[name]; !exists {
+			return false, fmt.Errorf("competition '%s' not found", id)
+		}
+		delete(entries, name)
+
+		// Its history, draft and cached results go with it
+		for entry := range entries {
+			if strings.HasPrefix(entry, archiveHistoryDir(id)) {
+				delete(entries, entry)
+			}
+		}
+		for _, kind := range competitionDocumentKinds {
+			delete(entries, archiveDocumentName(kind, id))
+		}
+		return true, nil
+	})
+}
+
+func (s *archiveStore) Rename(id, newName string) error {
+	comp, err := s.Load(id)
+	if err != nil {
+		return err
+	}
+	comp.Name = newName
+	return s.Save(comp)
+}
+
+func (s *archiveStore) Watch(onChange func()) (func(), error) {
+	name := filepath.Base(s.file)
+	stop, err := watchDirectory(filepath.Dir(s.file), func(changed string) bool {
+		return changed == name
+	}, onChange)
+	if err != nil {
+		log.Printf("Polling for changes instead: %v", err)
+		return pollWatch(s.List, storeWatchInterval, onChange), nil
+	}
+	return stop, nil
+}
+
+// archiveDocumentName returns the name of a document inside the archive
+func archiveDocumentName(kind, id string) string {
+	return path.Join(kind, competitionFileName(id))
+}
+
+func (s *archiveStore) ListDocuments(kind string) ([]StoredDocument, error) {
+	if !documentKinds[kind] {
+		return nil, fmt.Errorf("unknown document kind '%s'", kind)
+	}
+	s.mutex.Lock()
+	entries, err := s.read()
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []StoredDocument
+	prefix := kind + "/"
+	for name, entry := range entries {
+		id := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".json")
+		if !strings.HasPrefix(name, prefix) || path.Ext(name) != ".json" || !isValidCompetitionID(id) {
+			continue
+		}
+		documents = append(documents, StoredDocument{ID: id, Data: entry.Data, Modified: entry.Modified})
+	}
+	return documents, nil
+}
+
+func (s *archiveStore) ReadDocument(kind, id string) ([]byte, error) {
+	if err := validateDocument(kind, id); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	entries, err := s.read()
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if entry, exists := entries[archiveDocumentName(kind, id)]; exists {
+		return entry.Data, nil
+	}
+	return nil, nil
+}
+
+// WriteDocument leaves the archive untouched if the document did not change, so unchanged
+// drafts do not rewrite the whole archive
+func (s *archiveStore) WriteDocument(kind, id string, data []byte) error {
+	if err := validateDocument(kind, id); err != nil {
+		return err
+	}
+	return s.update(func(entries map[string]archiveEntry) (bool, error) {
+		name := archiveDocumentName(kind, id)
+		if previous, exists := entries[name]; exists && bytes.Equal(previous.Data, data) {
+			return false, nil
+		}
+		entries[name] = archiveEntry{Data: data, Modified: time.Now()}
+		return true, nil
+	})
+}
+
+func (s *archiveStore) DeleteDocument(kind, id string) error {
+	if err := validateDocument(kind, id); err != nil {
+		return err
+	}
+	return s.update(func(entries map[string]archiveEntry) (bool, error) {
+		name := archiveDocumentName(kind, id)
+		if _, exists := entries[name]; !exists {
+			return false, nil
+		}
+		delete(entries, name)
+		return true, nil
+	})
+}
+
+func (s *archiveStore) History(id string) ([]HistoryVersion, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return archiveHistory(entries, id), nil
+}
+
+func (s *archiveStore) LoadVersion(id string, version HistoryVersion) (Competition, error) {
+	if version.File != path.Base(version.File) {
+		return Competition{}, fmt.Errorf("invalid history file name '%s'", version.File)
+	}
+
+	s.mutex.Lock()
+	entries, err := s.read()
+	s.mutex.Unlock()
+	if err != nil {
+		return Competition{}, err
+	}
+
+	entry, exists := entries[archiveHistoryDir(id)+version.File]
+	if !exists {
+		return Competition{}, fmt.Errorf("failed to read version: %s not found", version.File)
+	}
+	comp, _, err := parseCompetition(entry.Data)
+	return comp, err
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	bundleFormat    = "aufguss-competition-bundle" // Identifies bundle files
+	bundleVersion   = 1                            // Bundle layout written by this build
+	bundleExtension = ".aufguss"
+)
+
+// CompetitionBundle is a single file carrying everything another organizer needs to take
+// over a competition. The competition is kept as raw JSON so it is migrated on import.
+type CompetitionBundle struct {
+	Format      string              `json:"format"`
+	Version     int                 `json:"version"`
+	ExportedAt  time.Time           `json:"exported_at"`
+	Competition json.RawMessage     `json:"competition"`
+	Template    TemplateReference   `json:"template"`
+	Generation  *GenerationManifest `json:"generation,omitempty"`
+	Results     json.RawMessage     `json:"results,omitempty"` // Cached results, if included
+}
+
+// encodeBundle serializes a competition with its template reference and optional results
+func encodeBundle(comp Competition, template TemplateReference, results json.RawMessage) ([]byte, error) {
+	bundle := CompetitionBundle{
+		Format:     bundleFormat,
+		Version:    bundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Template:   template,
+		Generation: comp.Generation,
+		Results:    results,
+	}
+
+	// The manifest is stored once, next to the competition
+	comp.Generation = nil
+	competition, err := encodeCompetition(comp)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Competition = competition
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize bundle: %w", err)
+	}
+	return data, nil
+}
+
+// decodeBundle reads a bundle file and returns the competition it contains
+func decodeBundle(data []byte) (Competition, CompetitionBundle, error) {
+	var bundle CompetitionBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return Competition{}, bundle, fmt.Errorf("The file is not a competition bundle.")
+	}
+	if bundle.Format != bundleFormat {
+		return Competition{}, bundle, fmt.Errorf("The file is not a competition bundle.")
+	}
+	if bundle.Version > bundleVersion {
+		return Competition{}, bundle, fmt.Errorf("The bundle was created by a newer version of the app (bundle version %d). Please update the app.", bundle.Version)
+	}
+	if len(bundle.Competition) == 0 {
+		return Competition{}, bundle, fmt.Errorf("The bundle does not contain a competition.")
+	}
+
+	comp, _, err := parseCompetition(bundle.Competition)
+	if err != nil {
+		return Competition{}, bundle, err
+	}
+	if !isValidCompetitionID(comp.ID) {
+		comp.ID = newCompetitionID()
+	}
+	comp.Generation = bundle.Generation
+	return comp, bundle, nil
+}
+
+// findCompetitionClash returns the stored competition an imported one collides with,
+// either because it has the same ID or the same name
+func findCompetitionClash(comp Competition, infos []CompetitionInfo) (CompetitionInfo, bool) {
+	for _, info := range infos {
+		if info.ID == comp.ID {
+			return info, true
+		}
+	}
+	for _, info := range infos {
+		if normalizeRosterName(info.Name) == normalizeRosterName(comp.Name) {
+			return info, true
+		}
+	}
+	return CompetitionInfo{}, false
+}
+
+// uniqueCompetitionName returns name, or a numbered variant of it that no stored competition uses
+func uniqueCompetitionName(name string, infos []CompetitionInfo) string {
+	used := make(map[string]bool)
+	for _, info := range infos {
+		used[normalizeRosterName(info.Name)] = true
+	}
+	candidate := name
+	for n := 1; used[normalizeRosterName(candidate)]; n++ {
+		if n == 1 {
+			candidate = fmt.Sprintf("%s (imported)", name)
+		} else {
+			candidate = fmt.Sprintf("%s (imported %d)", name, n)
+		}
+	}
+	return candidate
+}
+
+// prepareBundleImport resolves clashes with the stored competitions. With replace, the
+// imported competition takes the place of the clashing one; otherwise it is stored next
+// to it under a new ID and a unique name.
+func prepareBundleImport(comp Competition, infos []CompetitionInfo, replace bool) Competition {
+	clash, exists := findCompetitionClash(comp, infos)
+	if !exists {
+		return comp
+	}
+	if replace {
+		comp.ID = clash.ID
+		return comp
+	}
+	for _, info := range infos {
+		if info.ID == comp.ID {
+			comp.ID = newCompetitionID()
+			break
+		}
+	}
+	comp.Name = uniqueCompetitionName(strings.TrimSpace(comp.Name), infos)
+	return comp
+}
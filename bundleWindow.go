@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	log "github.com/s00500/env_logger"
+)
+
+// Bundle Export Function
+func exportCompetitionBundle(myApp fyne.App, parent fyne.Window, comp Competition, templateName string) {
+	results, err := readResultsCache(comp.ID)
+	if err != nil {
+		log.Printf("Failed to read cached results: %v", err)
+	}
+	includeResults := widget.NewCheck("Include cached results", nil)
+	if results == nil {
+		includeResults.Disable()
+	} else {
+		includeResults.SetChecked(true)
+	}
+
+	summary := fmt.Sprintf("Template: %s", templateName)
+	if comp.Generation != nil {
+		summary += fmt.Sprintf("\nGenerated spreadsheets: %d", comp.Generation.SpreadsheetCount())
+	} else {
+		summary += "\nThe competition has not been generated yet."
+	}
+
+	dialog.ShowCustomConfirm("Export Bundle", "Export...", "Cancel",
+		container.NewVBox(widget.NewLabel(summary), includeResults),
+		func(confirm bool) {
+			if !confirm {
+				return
+			}
+
+			// Prefer the template revision the sheets were generated from
+			var template TemplateReference
+			if comp.Generation != nil {
+				template = comp.Generation.Template
+			} else {
+				ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+				defer cancel()
+				template, err = lookupTemplateReference(ctx, myApp.Preferences().String("credentials"), comp.SourceSheetID)
+				if err != nil {
+					log.Printf("Exporting without template revision: %v", err)
+					template = TemplateReference{ID: comp.SourceSheetID, Name: templateName}
+				}
+			}
+
+			var bundledResults json.RawMessage
+			if includeResults.Checked {
+				bundledResults = results
+			}
+			data, err := encodeBundle(comp, template, bundledResults)
+			if err != nil {
+				dialog.ShowError(err, parent)
+				return
+			}
+
+			saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+				if err != nil || writer == nil {
+					if err != nil {
+						log.Printf("File selection error: %v", err)
+					}
+					return
+				}
+				defer writer.Close()
+				if _, err := writer.Write(data); err != nil {
+					dialog.ShowError(fmt.Errorf("Failed to write the bundle: %w", err), parent)
+					return
+				}
+				log.Printf("Exported %s to %s", comp.Name, writer.URI().Path())
+				dialog.ShowInformation("Export Complete", fmt.Sprintf("'%s' was exported to %s.", comp.Name, writer.URI().Name()), parent)
+			}, parent)
+			saveDialog.SetFileName(sanitizeFileName(comp.Name) + bundleExtension)
+			saveDialog.Show()
+		},
+		parent,
+	)
+}
+
+// Bundle Import Function
+func importCompetitionBundle(parent fyne.Window, hasTemplate func(id string) bool, onImported func(id string)) {
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil || reader == nil {
+			if err != nil {
+				log.Printf("File selection error: %v", err)
+			}
+			return
+		}
+		defer reader.Close()
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("Failed to read the file: %w", err), parent)
+			return
+		}
+		comp, bundle, err := decodeBundle(data)
+		if err != nil {
+			dialog.ShowError(err, parent)
+			return
+		}
+		infos, err := store.List()
+		if err != nil {
+			dialog.ShowError(err, parent)
+			return
+		}
+
+		finishImport := func(replace bool) {
+			imported := prepareBundleImport(comp, infos, replace)
+			if err := store.Save(imported); err != nil {
+				dialog.ShowError(err, parent)
+				return
+			}
+			if len(bundle.Results) > 0 {
+				if err := writeResultsCache(imported.ID, bundle.Results); err != nil {
+					log.Printf("Failed to store imported results: %v", err)
+				}
+			} else if replace {
+				// The results of the replaced competition do not belong to the imported one
+				if err := removeResultsCache(imported.ID); err != nil {
+					log.Printf("Failed to remove cached results: %v", err)
+				}
+			}
+			log.Printf("Imported %s as %s", comp.Name, imported.ID)
+			onImported(imported.ID)
+
+			notes := []string{fmt.Sprintf("'%s' was imported.", imported.Name)}
+			if bundle.Template.ID != "" && !hasTemplate(bundle.Template.ID) {
+				notes = append(notes, fmt.Sprintf("The template %s is not in your template folder. Ask the previous organizer to share it with your service account.", bundle.Template))
+			}
+			if count := bundle.Generation.SpreadsheetCount(); count > 0 {
+				notes = append(notes, fmt.Sprintf("The %d generated spreadsheets must be shared with your service account to read results.", count))
+			}
+			message := notes[0]
+			for _, note := range notes[1:] {
+				message += "\n\n" + note
+			}
+			dialog.ShowInformation("Import Complete", message, parent)
+		}
+
+		clash, exists := findCompetitionClash(comp, infos)
+		if !exists {
+			finishImport(false)
+			return
+		}
+
+		// Ask how to resolve the clash with the stored competition
+		var clashDialog dialog.Dialog
+		buttons := container.NewHBox(
+			layout.NewSpacer(),
+			widget.NewButton("Cancel", func() {
+				clashDialog.Hide()
+			}),
+			widget.NewButton("Replace", func() {
+				clashDialog.Hide()
+				finishImport(true)
+			}),
+			widget.NewButton("Keep Both", func() {
+				clashDialog.Hide()
+				finishImport(false)
+			}),
+		)
+		var reason string
+		switch {
+		case clash.ID != comp.ID:
+			reason = fmt.Sprintf("A competition named '%s' already exists.", clash.Name)
+		case normalizeRosterName(clash.Name) == normalizeRosterName(comp.Name):
+			reason = fmt.Sprintf("The competition '%s' already exists.", clash.Name)
+		default:
+			reason = fmt.Sprintf("The bundle contains '%s', which already exists under the name '%s'.", comp.Name, clash.Name)
+		}
+		message := widget.NewLabel(fmt.Sprintf("%s\nReplace it, or keep both and import as '%s'?",
+			reason, uniqueCompetitionName(comp.Name, infos)))
+		clashDialog = dialog.NewCustomWithoutButtons("Competition Exists", container.NewVBox(message, buttons), parent)
+		clashDialog.Show()
+	}, parent)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{bundleExtension}))
+	openDialog.Show()
+}
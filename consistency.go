@@ -0,0 +1,363 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Thresholds for flagging a juror as an outlier
+const (
+	outlierCorrelation = 0.5 // Minimum rank correlation with the consensus ranking
+	outlierBias        = 0.5 // Maximum bias as a share of the panel's typical spread
+	outlierSpread      = 2.0 // Maximum factor between a juror's spread and the panel's typical spread
+)
+
+// JurorConsistency describes how one juror scored compared with the panel
+type JurorConsistency struct {
+	Juror       string
+	Scored      int      // Contestants the juror scored
+	Mean        float64  // Mean score the juror gave
+	Bias        float64  // Mean difference to the panel mean of the same contestants
+	Spread      float64  // Standard deviation of the juror's scores
+	Correlation *float64 // Spearman correlation with the final ranking, nil if it cannot be computed
+	Outlier     bool
+	Reasons     []string
+}
+
+// ConsistencyReport is the consistency analysis of a jury
+type ConsistencyReport struct {
+	Jurors      []JurorConsistency
+	PanelSpread float64  // Median of the juror spreads
+	Contestants int      // Contestants scored by every juror, used for the panel-wide agreement
+	KendallW    *float64 // Kendall's coefficient of concordance of the jurors' rankings
+	ICC         *float64 // Intraclass correlation ICC(2,1), absolute agreement of single jurors
+	Warnings    []string
+}
+
+// analyzeConsistency compares each juror's scores with the panel and measures how well the
+// panel agrees. Scores are the juror totals of the standings.
+func analyzeConsistency(standings *Standings) *ConsistencyReport {
+	report := &ConsistencyReport{}
+	results := standings.Results
+
+	// Panel mean of each contestant over the jurors who scored it
+	panelMeans := make([]*float64, len(results))
+	for i, result := range results {
+		var values []float64
+		for _, score := range result.JurorTotals {
+			if score != nil {
+				values = append(values, *score)
+			}
+		}
+		if len(values) > 0 {
+			mean := meanOf(values)
+			panelMeans[i] = &mean
+		}
+	}
+
+	// The final ranking is the consensus the jurors are compared with
+	consensus := make([]float64, len(results))
+	for i, result := range results {
+		consensus[i] = float64(result.Rank)
+	}
+
+	var spreads []float64
+	for j, name := range standings.Jurors {
+		juror := JurorConsistency{Juror: name}
+		column := make([]*float64, len(results))
+		var values, differences, jurorRanks, consensusRanks []float64
+		for i, result := range results {
+			if j >= len(result.JurorTotals) || result.JurorTotals[j] == nil {
+				continue
+			}
+			column[i] = result.JurorTotals[j]
+			values = append(values, *result.JurorTotals[j])
+			differences = append(differences, *result.JurorTotals[j]-*panelMeans[i])
+		}
+		for i, rank := range averageRanks(column) {
+			if rank != nil {
+				jurorRanks = append(jurorRanks, *rank)
+				consensusRanks = append(consensusRanks, consensus[i])
+			}
+		}
+
+		juror.Scored = len(values)
+		if juror.Scored == 0 {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s has not scored any contestant.", name))
+			report.Jurors = append(report.Jurors, juror)
+			continue
+		}
+		juror.Mean = meanOf(values)
+		juror.Bias = meanOf(differences)
+		juror.Spread = standardDeviation(values)
+		// Ranks run from best to worst in both, so agreeing jurors correlate positively
+		juror.Correlation = pearsonCorrelation(jurorRanks, consensusRanks)
+		spreads = append(spreads, juror.Spread)
+		report.Jurors = append(report.Jurors, juror)
+	}
+	report.PanelSpread = median(spreads)
+
+	for j := range report.Jurors {
+		juror := &report.Jurors[j]
+		if juror.Scored == 0 {
+			continue
+		}
+		if juror.Correlation != nil && *juror.Correlation < outlierCorrelation {
+			juror.Reasons = append(juror.Reasons, "ranks differently from the panel")
+		}
+		if report.PanelSpread > 0 {
+			if math.Abs(juror.Bias) > outlierBias*report.PanelSpread {
+				if juror.Bias > 0 {
+					juror.Reasons = append(juror.Reasons, "scores higher than the panel")
+				} else {
+					juror.Reasons = append(juror.Reasons, "scores lower than the panel")
+				}
+			}
+			if juror.Spread > outlierSpread*report.PanelSpread {
+				juror.Reasons = append(juror.Reasons, "spreads scores widely")
+			} else if juror.Spread < report.PanelSpread/outlierSpread {
+				juror.Reasons = append(juror.Reasons, "spreads scores narrowly")
+			}
+		}
+		juror.Outlier = len(juror.Reasons) > 0
+	}
+
+	// Panel-wide agreement over the contestants every scoring juror scored
+	var jurors []int
+	for j, juror := range report.Jurors {
+		if juror.Scored > 0 {
+			jurors = append(jurors, j)
+		}
+	}
+	var matrix [][]float64 // Contestants by jurors
+	for _, result := range results {
+		row := make([]float64, 0, len(jurors))
+		for _, j := range jurors {
+			if j < len(result.JurorTotals) && result.JurorTotals[j] != nil {
+				row = append(row, *result.JurorTotals[j])
+			}
+		}
+		if len(row) == len(jurors) {
+			matrix = append(matrix, row)
+		}
+	}
+	report.Contestants = len(matrix)
+	if skipped := len(results) - len(matrix); skipped > 0 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%d contestant(s) not scored by every juror are left out of the panel-wide agreement.", skipped))
+	}
+	if len(jurors) < 2 || len(matrix) < 2 {
+		report.Warnings = append(report.Warnings, "The panel-wide agreement needs at least two jurors and two contestants they all scored.")
+		return report
+	}
+	report.KendallW = kendallW(matrix)
+	report.ICC = intraclassCorrelation(matrix)
+	return report
+}
+
+// meanOf returns the mean of the values
+func meanOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// standardDeviation returns the population standard deviation of the values
+func standardDeviation(values []float64) float64 {
+	mean := meanOf(values)
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	if len(values) == 0 {
+		return 0
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// pearsonCorrelation returns the correlation of two series, or nil if there are fewer than
+// three pairs or one series is constant. Applied to ranks, it is Spearman's correlation.
+func pearsonCorrelation(a, b []float64) *float64 {
+	if len(a) < 3 || len(a) != len(b) {
+		return nil
+	}
+	meanA, meanB := meanOf(a), meanOf(b)
+	var covariance, varianceA, varianceB float64
+	for i := range a {
+		covariance += (a[i] - meanA) * (b[i] - meanB)
+		varianceA += (a[i] - meanA) * (a[i] - meanA)
+		varianceB += (b[i] - meanB) * (b[i] - meanB)
+	}
+	if varianceA == 0 || varianceB == 0 {
+		return nil
+	}
+	correlation := covariance / math.Sqrt(varianceA*varianceB)
+	return &correlation
+}
+
+// kendallW returns Kendall's coefficient of concordance of a contestants-by-jurors matrix,
+// corrected for tied ranks. 1 means all jurors rank the contestants alike.
+func kendallW(matrix [][]float64) *float64 {
+	n, m := len(matrix), len(matrix[0])
+	rankSums := make([]float64, n)
+	ties := 0.0
+	for j := 0; j < m; j++ {
+		column := make([]*float64, n)
+		counts := make(map[float64]int)
+		for i := range matrix {
+			score := matrix[i][j]
+			column[i] = &score
+		}
+		for i, rank := range averageRanks(column) {
+			rankSums[i] += *rank
+			counts[*rank]++
+		}
+		for _, t := range counts {
+			ties += float64(t*t*t - t)
+		}
+	}
+
+	mean := meanOf(rankSums)
+	deviations := 0.0
+	for _, sum := range rankSums {
+		deviations += (sum - mean) * (sum - mean)
+	}
+	denominator := float64(m*m)*float64(n*n*n-n) - float64(m)*ties
+	if denominator <= 0 {
+		return nil
+	}
+	w := 12 * deviations / denominator
+	return &w
+}
+
+// intraclassCorrelation returns ICC(2,1) of a contestants-by-jurors matrix: the two-way
+// random effects model for the absolute agreement of single jurors.
+func intraclassCorrelation(matrix [][]float64) *float64 {
+	n, k := len(matrix), len(matrix[0])
+	grand := 0.0
+	rowMeans := make([]float64, n)
+	colMeans := make([]float64, k)
+	for i, row := range matrix {
+		rowMeans[i] = meanOf(row)
+		for j, value := range row {
+			colMeans[j] += value / float64(n)
+			grand += value / float64(n*k)
+		}
+	}
+
+	var rowsSquares, colsSquares, errorSquares float64
+	for i, row := range matrix {
+		rowsSquares += float64(k) * (rowMeans[i] - grand) * (rowMeans[i] - grand)
+		for j, value := range row {
+			residual := value - rowMeans[i] - colMeans[j] + grand
+			errorSquares += residual * residual
+		}
+	}
+	for j := range colMeans {
+		colsSquares += float64(n) * (colMeans[j] - grand) * (colMeans[j] - grand)
+	}
+	msr := rowsSquares / float64(n-1)
+	msc := colsSquares / float64(k-1)
+	mse := errorSquares / float64((n-1)*(k-1))
+
+	denominator := msr + float64(k-1)*mse + float64(k)*(msc-mse)/float64(n)
+	if denominator == 0 {
+		return nil
+	}
+	icc := (msr - mse) / denominator
+	return &icc
+}
+
+// describeKendallW interprets Kendall's W
+func describeKendallW(w float64) string {
+	switch {
+	case w < 0.3:
+		return "weak agreement"
+	case w < 0.6:
+		return "moderate agreement"
+	}
+	return "strong agreement"
+}
+
+// describeICC interprets an intraclass correlation after Koo and Li
+func describeICC(icc float64) string {
+	switch {
+	case icc < 0.5:
+		return "poor reliability"
+	case icc < 0.75:
+		return "moderate reliability"
+	case icc < 0.9:
+		return "good reliability"
+	}
+	return "excellent reliability"
+}
+
+// formatCorrelation formats an optional coefficient
+func formatCorrelation(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *value)
+}
+
+// consistencyMarkdown exports the analysis as a Markdown report
+func consistencyMarkdown(comp Competition, report *ConsistencyReport) []byte {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# Jury Consistency - %s\n\n", comp.Name)
+	fmt.Fprintf(&builder, "Generated %s\n\n", time.Now().Format("2006-01-02 15:04"))
+
+	builder.WriteString("## Panel\n\n")
+	if report.KendallW != nil {
+		fmt.Fprintf(&builder, "- Kendall's W: %.2f (%s)\n", *report.KendallW, describeKendallW(*report.KendallW))
+	}
+	if report.ICC != nil {
+		fmt.Fprintf(&builder, "- ICC(2,1): %.2f (%s)\n", *report.ICC, describeICC(*report.ICC))
+	}
+	fmt.Fprintf(&builder, "- Contestants scored by every juror: %d\n", report.Contestants)
+	fmt.Fprintf(&builder, "- Typical spread of a juror's scores: %s\n\n", formatScore(report.PanelSpread))
+
+	builder.WriteString("## Jurors\n\n")
+	builder.WriteString("| Juror | Scored | Mean | Bias | Spread | Correlation | Notes |\n")
+	builder.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, juror := range report.Jurors {
+		fmt.Fprintf(&builder, "| %s | %d | %s | %+.2f | %s | %s | %s |\n",
+			markdownCell(juror.Juror), juror.Scored, formatScore(juror.Mean), juror.Bias, formatScore(juror.Spread),
+			formatCorrelation(juror.Correlation), markdownCell(strings.Join(juror.Reasons, ", ")))
+	}
+
+	if len(report.Warnings) > 0 {
+		builder.WriteString("\n## Notes\n\n")
+		for _, warning := range report.Warnings {
+			fmt.Fprintf(&builder, "- %s\n", warning)
+		}
+	}
+
+	builder.WriteString("\nBias is the mean difference to the panel mean of the same contestants. Correlation is Spearman's rank correlation with the final ranking. ")
+	fmt.Fprintf(&builder, "Jurors are flagged if their correlation is below %.1f, their bias exceeds %.0f%% of the typical spread, or their spread differs from it by more than a factor of %.0f.\n",
+		outlierCorrelation, outlierBias*100, outlierSpread)
+	return []byte(builder.String())
+}
+
+// markdownCell escapes text for a Markdown table cell
+func markdownCell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", `\|`), "\n", " ")
+}
+
+// sortedOutliers returns the names of the flagged jurors, sorted
+func (r *ConsistencyReport) sortedOutliers() []string {
+	var names []string
+	for _, juror := range r.Jurors {
+		if juror.Outlier {
+			names = append(names, juror.Juror)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	log "github.com/s00500/env_logger"
+)
+
+// Consistency Analysis Window Function
+func showConsistencyAnalysis(myApp fyne.App, comp Competition, standings *Standings) {
+	analysisWindow := myApp.NewWindow(fmt.Sprintf("Jury Consistency of '%s'", comp.Name))
+	analysisWindow.Resize(fyne.NewSize(850, 450))
+	report := analyzeConsistency(standings)
+
+	columns := []string{"Juror", "Scored", "Mean", "Bias", "Spread", "Correlation", "Notes"}
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(report.Jurors), len(columns)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			juror := report.Jurors[id.Row]
+			label := obj.(*widget.Label)
+			label.Importance = widget.MediumImportance
+			if juror.Outlier {
+				label.Importance = widget.DangerImportance
+			}
+			switch id.Col {
+			case 0:
+				label.SetText(juror.Juror)
+			case 1:
+				label.SetText(fmt.Sprintf("%d", juror.Scored))
+			case 2:
+				label.SetText(formatScore(juror.Mean))
+			case 3:
+				label.SetText(fmt.Sprintf("%+.2f", juror.Bias))
+			case 4:
+				label.SetText(formatScore(juror.Spread))
+			case 5:
+				label.SetText(formatCorrelation(juror.Correlation))
+			case 6:
+				label.SetText(strings.Join(juror.Reasons, ", "))
+			}
+		},
+	)
+	table.ShowHeaderRow = true
+	table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	}
+	table.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		if id.Col >= 0 {
+			obj.(*widget.Label).SetText(columns[id.Col])
+		}
+	}
+	for col, width := range []float32{180, 70, 70, 70, 70, 100, 280} {
+		table.SetColumnWidth(col, width)
+	}
+
+	// Panel-wide agreement
+	var summary []string
+	if report.KendallW != nil {
+		summary = append(summary, fmt.Sprintf("Kendall's W: %.2f (%s)", *report.KendallW, describeKendallW(*report.KendallW)))
+	}
+	if report.ICC != nil {
+		summary = append(summary, fmt.Sprintf("ICC(2,1): %.2f (%s)", *report.ICC, describeICC(*report.ICC)))
+	}
+	summary = append(summary, fmt.Sprintf("Contestants scored by every juror: %d", report.Contestants))
+	if outliers := report.sortedOutliers(); len(outliers) > 0 {
+		summary = append(summary, fmt.Sprintf("Outliers: %s", strings.Join(outliers, ", ")))
+	} else {
+		summary = append(summary, "No outliers")
+	}
+	summaryLabel := widget.NewLabel(strings.Join(summary, "\n"))
+
+	notes := append([]string(nil), report.Warnings...)
+	notes = append(notes, fmt.Sprintf("Bias is the mean difference to the panel mean of the same contestants. Correlation is the rank correlation with the final ranking. Jurors are flagged if their correlation is below %.1f, their bias exceeds %.0f%% of the typical spread (%s), or their spread differs from it by more than a factor of %.0f.",
+		outlierCorrelation, outlierBias*100, formatScore(report.PanelSpread), outlierSpread))
+	notesLabel := widget.NewLabel(strings.Join(notes, "\n"))
+	notesLabel.Wrapping = fyne.TextWrapWord
+
+	exportButton := widget.NewButton("Export Report...", func() {
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				if err != nil {
+					log.Printf("File selection error: %v", err)
+				}
+				return
+			}
+			defer writer.Close()
+			if _, err := writer.Write(consistencyMarkdown(comp, report)); err != nil {
+				dialog.ShowError(fmt.Errorf("Failed to write the report: %w", err), analysisWindow)
+			}
+		}, analysisWindow)
+		saveDialog.SetFileName(sanitizeFileName(comp.Name) + " - Jury Consistency.md")
+		saveDialog.Show()
+	})
+
+	analysisWindow.SetContent(container.NewBorder(
+		summaryLabel,
+		container.NewVBox(
+			notesLabel,
+			container.NewHBox(
+				layout.NewSpacer(),
+				exportButton,
+				widget.NewButton("Close", func() {
+					analysisWindow.Close()
+				}),
+			),
+		),
+		nil, nil,
+		table,
+	))
+	analysisWindow.Show()
+}
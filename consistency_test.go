@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// shroutFleiss is the example of Shrout and Fleiss (1979): six targets rated by four judges
+var shroutFleiss = [][]float64{
+	{9, 2, 5, 8},
+	{6, 1, 3, 2},
+	{8, 4, 6, 8},
+	{7, 1, 2, 6},
+	{10, 5, 6, 9},
+	{6, 2, 4, 7},
+}
+
+func TestKendallW(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64 // Contestants by jurors, higher scores rank first
+		want   float64
+	}{
+		{"identical rankings", [][]float64{{9, 8, 10}, {7, 6, 9}, {5, 4, 8}, {3, 2, 7}}, 1},
+		{"opposite rankings", [][]float64{{9, 1}, {5, 5}, {1, 9}}, 0},
+		// Rank sums 4, 6 and 8: W = 12 * 8 / (3² * (3³ - 3)) = 4/9
+		{"partial agreement", [][]float64{{9, 9, 8}, {8, 7, 9}, {7, 8, 7}}, 4.0 / 9},
+		// The first juror ties two contestants (ranks 1.5 and 1.5); rank sums 3.5, 5.5 and 9
+		// give W = 12 * 15.5 / (3² * 24 - 3 * (2³ - 2)) = 186/198
+		{"tied ranks", [][]float64{{9, 9, 9}, {9, 8, 8}, {7, 7, 7}}, 186.0 / 198},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := kendallW(test.matrix)
+			if w == nil || math.Abs(*w-test.want) > 1e-9 {
+				t.Errorf("kendallW = %v, want %.4f", w, test.want)
+			}
+		})
+	}
+}
+
+func TestIntraclassCorrelation(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix [][]float64
+		want   float64
+	}{
+		// Shrout and Fleiss report ICC(2,1) = 0.29 for their example
+		{"Shrout and Fleiss", shroutFleiss, 0.2898},
+		{"perfect agreement", [][]float64{{9, 9, 9}, {6, 6, 6}, {3, 3, 3}}, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			icc := intraclassCorrelation(test.matrix)
+			if icc == nil || math.Abs(*icc-test.want) > 0.0001 {
+				t.Errorf("intraclassCorrelation = %v, want %.4f", icc, test.want)
+			}
+		})
+	}
+
+	// Identical scores everywhere leave nothing to correlate
+	if icc := intraclassCorrelation([][]float64{{5, 5}, {5, 5}}); icc != nil {
+		t.Errorf("intraclassCorrelation of constant scores = %.4f, want nil", *icc)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	log "github.com/s00500/env_logger"
+)
+
+const (
+	appDataFolder          = "Aufguss Scoring Generator" // Folder inside the OS user data directory
+	dataDirPreference      = "data_dir"                  // Preference overriding the data directory
+	archiveFilePreference  = "archive_file"              // Preference selecting a portable competition archive
+	migrationPrefOffered   = "legacy_migration_offered"  // Set once the user was asked to migrate
+	legacyAppBundleSegment = "Aufguss Scoring Generator.app"
+)
+
+// defaultDataDir returns the competitions folder inside the OS user data directory
+func defaultDataDir() (string, error) {
+	var base string
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		configDir, err := os.UserConfigDir() // ~/Library/Application Support or %AppData%
+		if err != nil {
+			return "", err
+		}
+		base = configDir
+	default:
+		// Follow the XDG base directory specification
+		if xdgDataHome := os.Getenv("XDG_DATA_HOME"); xdgDataHome != "" && filepath.IsAbs(xdgDataHome) {
+			base = xdgDataHome
+		} else {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			base = filepath.Join(home, ".local", "share")
+		}
+	}
+	return filepath.Join(base, appDataFolder, competitionsDir), nil
+}
+
+// legacyDataDir returns the folder earlier versions stored competitions in, next to the executable
+func legacyDataDir() (string, error) {
+	execPath, err := getExecutableDir()
+	if err != nil {
+		return "", err
+	}
+
+	exePathParts := strings.Split(execPath, legacyAppBundleSegment)
+	basePath := ""
+	if len(exePathParts) == 2 {
+		basePath = exePathParts[0]
+	}
+	return filepath.Join(basePath, competitionsDir), nil
+}
+
+// resolveDataDir returns the data directory from the preferences, or the default one
+func resolveDataDir(myApp fyne.App) (string, error) {
+	if override := strings.TrimSpace(myApp.Preferences().String(dataDirPreference)); override != "" {
+		return filepath.Clean(override), nil
+	}
+	return defaultDataDir()
+}
+
+// countCompetitionFiles returns the number of competition files in a folder
+func countCompetitionFiles(dir string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && isCompetitionFileName(entry.Name()) {
+			count++
+		}
+	}
+	return count
+}
+
+// offerLegacyMigration asks once whether competitions found next to the executable should be
+// copied to the data directory. onMigrated is called after files were copied.
+func offerLegacyMigration(myApp fyne.App, parent fyne.Window, onMigrated func()) {
+	if myApp.Preferences().Bool(migrationPrefOffered) {
+		return
+	}
+
+	legacyDir, err := legacyDataDir()
+	if err != nil {
+		return
+	}
+	absLegacy, _ := filepath.Abs(legacyDir)
+	absData, _ := filepath.Abs(dataDir)
+	if absLegacy == absData {
+		return
+	}
+	count := countCompetitionFiles(legacyDir)
+	if count == 0 {
+		return
+	}
+
+	dialog.ShowConfirm("Migrate Competitions",
+		fmt.Sprintf("%d competition(s) were found in the old location:\n%s\n\nCopy them to the new data folder?\n%s", count, legacyDir, dataDir),
+		func(confirm bool) {
+			myApp.Preferences().SetBool(migrationPrefOffered, true)
+			if !confirm {
+				return
+			}
+			copied, err := copyDataDir(legacyDir, dataDir)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("Migration failed after %d file(s): %w", copied, err), parent)
+				return
+			}
+			log.Printf("Copied %d file(s) from %s to %s", copied, legacyDir, dataDir)
+			dialog.ShowInformation("Migration Complete",
+				fmt.Sprintf("%d file(s) were copied. The old folder was left unchanged.", copied), parent)
+			if onMigrated != nil {
+				onMigrated()
+			}
+		},
+		parent,
+	)
+}
+
+// copyDataDir copies all files from one data directory to another, including subfolders.
+// Files that already exist in the destination are left untouched.
+func copyDataDir(from, to string) (int, error) {
+	copied := 0
+	err := filepath.WalkDir(from, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(to, relative)
+
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if _, err := os.Stat(target); err == nil {
+			return nil // Never overwrite
+		}
+		if err := copyFile(path, target); err != nil {
+			return err
+		}
+		copied++
+		return nil
+	})
+	return copied, err
+}
+
+func copyFile(from, to string) error {
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	data, err := io.ReadAll(source)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(to, data, 0644)
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/s00500/env_logger"
+)
+
+// dirStore keeps one JSON file per competition in a directory, named after the competition ID.
+// Files of older schema versions may still be named after the competition; their ID is the
+// file name without extension until they are migrated on load.
+type dirStore struct {
+	dir string
+}
+
+// newDirStore returns a store for the competitions in dir
+func newDirStore(dir string) *dirStore {
+	return &dirStore{dir: dir}
+}
+
+// path returns the file path of a competition, rejecting IDs that would escape the directory
+func (s *dirStore) path(id string) (string, error) {
+	file := competitionFileName(id)
+	if id == "" || file != filepath.Base(file) || strings.HasPrefix(file, ".") {
+		return "", fmt.Errorf("invalid competition ID '%s'", id)
+	}
+	return filepath.Join(s.dir, file), nil
+}
+
+func (s *dirStore) Location() string {
+	return s.dir
+}
+
+func (s *dirStore) List() ([]CompetitionInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []CompetitionInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	infos := []CompetitionInfo{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !isCompetitionFileName(name) {
+			continue
+		}
+		id := strings.TrimSuffix(name, ".json")
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			log.Printf("Skipping unreadable competition file %s: %v", name, err)
+			continue
+		}
+		info := CompetitionInfo{ID: id, Name: competitionName(data, id)}
+		if fileInfo, err := entry.Info(); err == nil {
+			info.Modified = fileInfo.ModTime()
+		}
+		infos = append(infos, info)
+	}
+	sortCompetitionInfos(infos)
+	return infos, nil
+}
+
+func (s *dirStore) Load(id string) (Competition, error) {
+	filePath, err := s.path(id)
+	if err != nil {
+		return Competition{}, err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return Competition{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	comp, version, err := parseCompetition(data)
+	if err != nil {
+		return Competition{}, err
+	}
+	if !isValidCompetitionID(comp.ID) {
+		return Competition{}, fmt.Errorf("the file has an invalid competition ID '%s'", comp.ID)
+	}
+
+	// Store the upgraded file, keeping the original in the backups folder
+	if version < currentSchemaVersion {
+		backupPath, err := backupCompetitionFile(s.dir, filePath, version)
+		if err != nil {
+			return Competition{}, fmt.Errorf("failed to back up file before migration: %w", err)
+		}
+		if err := s.Save(comp); err != nil {
+			return Competition{}, fmt.Errorf("failed to save migrated competition: %w", err)
+		}
+		if comp.ID != id {
+			if err := os.Remove(filePath); err != nil {
+				log.Printf("Failed to remove migrated file %s: %v", filePath, err)
+			}
+		}
+		log.Printf("Migrated %s from schema version %d to %d, original saved as %s", filepath.Base(filePath), version, currentSchemaVersion, backupPath)
+	}
+	return comp, nil
+}
+
+func (s *dirStore) Save(comp Competition) error {
+	if !isValidCompetitionID(comp.ID) {
+		return fmt.Errorf("invalid competition ID '%s'", comp.ID)
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	filePath, err := s.path(comp.ID)
+	if err != nil {
+		return err
+	}
+	// Keep the previous state in the history before it is replaced
+	if err := archiveCompetitionVersion(s.dir, comp.ID, filePath); err != nil {
+		log.Printf("Failed to add %s to the history: %v", filePath, err)
+	}
+
+	data, err := encodeCompetition(comp)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to save competition: %w", err)
+	}
+	return nil
+}
+
+func (s *dirStore) Delete(id string) error {
+	filePath, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil {
+		return fmt.Errorf("failed to delete competition: %w", err)
+	}
+
+	// Its history, draft and cached results go with it
+	if err := os.RemoveAll(competitionHistoryDir(s.dir, id)); err != nil {
+		return fmt.Errorf("failed to delete history: %w", err)
+	}
+	if !isValidCompetitionID(id) {
+		return nil // Not migrated yet, so there are no documents
+	}
+	for _, kind := range competitionDocumentKinds {
+		if err := s.DeleteDocument(kind, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *dirStore) Rename(id, newName string) error {
+	comp, err := s.Load(id)
+	if err != nil {
+		return err
+	}
+	comp.Name = newName
+	return s.Save(comp)
+}
+
+func (s *dirStore) Watch(onChange func()) (func(), error) {
+	stop, err := watchDirectory(s.dir, isCompetitionFileName, onChange)
+	if err != nil {
+		log.Printf("Polling for changes instead: %v", err)
+		return pollWatch(s.List, storeWatchInterval, onChange), nil
+	}
+	return stop, nil
+}
+
+// documentPath returns the file of a document, in the subfolder named after its kind
+func (s *dirStore) documentPath(kind, id string) (string, error) {
+	if err := validateDocument(kind, id); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, kind, competitionFileName(id)), nil
+}
+
+func (s *dirStore) ListDocuments(kind string) ([]StoredDocument, error) {
+	if !documentKinds[kind] {
+		return nil, fmt.Errorf("unknown document kind '%s'", kind)
+	}
+	entries, err := os.ReadDir(filepath.Join(s.dir, kind))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s directory: %w", kind, err)
+	}
+
+	var documents []StoredDocument
+	for _, entry := range entries {
+		id := strings.TrimSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !isCompetitionFileName(entry.Name()) || !isValidCompetitionID(id) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, kind, entry.Name()))
+		if err != nil {
+			log.Printf("Skipping unreadable file %s: %v", entry.Name(), err)
+			continue
+		}
+		documents = append(documents, StoredDocument{ID: id, Data: data, Modified: info.ModTime()})
+	}
+	return documents, nil
+}
+
+func (s *dirStore) ReadDocument(kind, id string) ([]byte, error) {
+	filePath, err := s.documentPath(kind, id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+func (s *dirStore) WriteDocument(kind, id string, data []byte) error {
+	filePath, err := s.documentPath(kind, id)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", kind, err)
+	}
+	return writeFileAtomic(filePath, data, 0644)
+}
+
+func (s *dirStore) DeleteDocument(kind, id string) error {
+	filePath, err := s.documentPath(kind, id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// isCompetitionFileName reports whether a file in the directory holds a competition.
+// Hidden files include the temporary files of atomic saves.
+func isCompetitionFileName(name string) bool {
+	return filepath.Ext(name) == ".json" && !strings.HasPrefix(name, ".")
+}
+
+func (s *dirStore) History(id string) ([]HistoryVersion, error) {
+	if !isValidCompetitionID(id) {
+		return nil, fmt.Errorf("invalid competition ID '%s'", id)
+	}
+	return listHistory(s.dir, id)
+}
+
+func (s *dirStore) LoadVersion(id string, version HistoryVersion) (Competition, error) {
+	if !isValidCompetitionID(id) {
+		return Competition{}, fmt.Errorf("invalid competition ID '%s'", id)
+	}
+	return loadHistoryVersion(s.dir, id, version)
+}
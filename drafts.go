@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	draftsDir        = "drafts"         // Store folder holding autosaved drafts
+	autosaveInterval = 30 * time.Second // How often unsaved changes are written to a draft
+)
+
+// Draft is an autosaved copy of a competition with unsaved changes. Drafts of competitions
+// that were never saved have no competition ID, so they are stored under their own key.
+type Draft struct {
+	Key         string
+	Competition Competition
+	Saved       time.Time
+}
+
+// writeDraft stores the current state of a competition, encoded with encodeCompetition
+func writeDraft(key string, data []byte) error {
+	if err := store.WriteDocument(draftsDir, key, data); err != nil {
+		return fmt.Errorf("failed to save draft: %w", err)
+	}
+	return nil
+}
+
+// draftExists reports whether there is a draft stored under key
+func draftExists(key string) bool {
+	data, err := store.ReadDocument(draftsDir, key)
+	return err == nil && data != nil
+}
+
+// removeDraft deletes a draft, if there is one
+func removeDraft(key string) error {
+	if err := store.DeleteDocument(draftsDir, key); err != nil {
+		return fmt.Errorf("failed to remove draft: %w", err)
+	}
+	return nil
+}
+
+// listDrafts returns all drafts, newest first. Damaged drafts are skipped.
+func listDrafts() ([]Draft, error) {
+	documents, err := store.ListDocuments(draftsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read drafts: %w", err)
+	}
+
+	var drafts []Draft
+	for _, document := range documents {
+		comp, _, err := parseCompetition(document.Data)
+		if err != nil {
+			continue
+		}
+		drafts = append(drafts, Draft{
+			Key:         document.ID,
+			Competition: comp,
+			Saved:       document.Modified,
+		})
+	}
+	sort.Slice(drafts, func(i, j int) bool {
+		return drafts[i].Saved.After(drafts[j].Saved)
+	})
+	return drafts, nil
+}
+
+// recoverableDrafts returns the drafts that are newer than the stored competition and removes
+// the ones that were superseded by a later save
+func recoverableDrafts() ([]Draft, error) {
+	drafts, err := listDrafts()
+	if err != nil {
+		return nil, err
+	}
+	var recoverable []Draft
+	for _, draft := range drafts {
+		if draft.Competition.ID != "" {
+			if modified := storedModified(draft.Competition.ID); !modified.IsZero() && !draft.Saved.After(modified) {
+				removeDraft(draft.Key)
+				continue
+			}
+		}
+		recoverable = append(recoverable, draft)
+	}
+	return recoverable, nil
+}
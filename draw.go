@@ -0,0 +1,179 @@
+package main
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+const maxDrawAttempts = 10000 // Shuffles tried before the constraints are considered unsatisfiable
+
+// DrawConstraints are the rules a start-order draw must respect
+type DrawConstraints struct {
+	PreviousWinner string `json:"previous_winner,omitempty"` // Always performs last
+	SeparateTeams  bool   `json:"separate_teams"`            // Team members never perform back-to-back
+}
+
+// DrawEntrant is a contestant as seen by the draw
+type DrawEntrant struct {
+	Name string `json:"name"`
+	Team string `json:"team,omitempty"`
+}
+
+// DrawRecord documents a start-order draw so it can be re-run and verified later.
+// Entrants are stored sorted by name, so the result does not depend on the order
+// the contestants were typed in.
+type DrawRecord struct {
+	Seed        int64           `json:"seed"`
+	Timestamp   time.Time       `json:"timestamp"`
+	Constraints DrawConstraints `json:"constraints"`
+	Entrants    []DrawEntrant   `json:"entrants"`
+	Order       []string        `json:"order"`
+	Attempts    int             `json:"attempts"`
+}
+
+// newDrawSeed returns a random seed for a new draw
+func newDrawSeed() int64 {
+	var buf [8]byte
+	if _, err := crand.Read(buf[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.BigEndian.Uint64(buf[:]) >> 1) // Keep the seed positive so it is easy to type
+}
+
+// drawEntrants returns the contestants as canonically sorted draw entrants
+func drawEntrants(contestants []*Contestant) []DrawEntrant {
+	entrants := make([]DrawEntrant, len(contestants))
+	for i, contestant := range contestants {
+		entrants[i] = DrawEntrant{Name: contestant.Name, Team: contestant.Team}
+	}
+	sort.SliceStable(entrants, func(i, j int) bool {
+		return entrants[i].Name < entrants[j].Name
+	})
+	return entrants
+}
+
+// performDraw shuffles the entrants with the given seed until the constraints are met
+func performDraw(entrants []DrawEntrant, constraints DrawConstraints, seed int64) (*DrawRecord, error) {
+	// Set the previous winner aside, they are placed last
+	pool := []DrawEntrant{}
+	var winner *DrawEntrant
+	for i, entrant := range entrants {
+		if constraints.PreviousWinner != "" && entrant.Name == constraints.PreviousWinner && winner == nil {
+			winner = &entrants[i]
+			continue
+		}
+		pool = append(pool, entrant)
+	}
+	if constraints.PreviousWinner != "" && winner == nil {
+		return nil, fmt.Errorf("previous winner '%s' is not among the contestants", constraints.PreviousWinner)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for attempt := 1; attempt <= maxDrawAttempts; attempt++ {
+		order := make([]DrawEntrant, len(pool), len(entrants))
+		copy(order, pool)
+		rng.Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+		if winner != nil {
+			order = append(order, *winner)
+		}
+
+		if constraints.SeparateTeams && !teamsSeparated(order) {
+			continue
+		}
+
+		record := &DrawRecord{
+			Seed:        seed,
+			Constraints: constraints,
+			Entrants:    entrants,
+			Order:       make([]string, len(order)),
+			Attempts:    attempt,
+		}
+		for i, entrant := range order {
+			record.Order[i] = entrant.Name
+		}
+		return record, nil
+	}
+	return nil, fmt.Errorf("no order satisfying the constraints was found in %d attempts", maxDrawAttempts)
+}
+
+// teamsSeparated reports whether no two members of the same team follow each other
+func teamsSeparated(order []DrawEntrant) bool {
+	for i := 1; i < len(order); i++ {
+		team := strings.TrimSpace(order[i].Team)
+		if team != "" && strings.EqualFold(team, strings.TrimSpace(order[i-1].Team)) {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyDraw re-runs a recorded draw and checks that it produces the recorded order
+func verifyDraw(record *DrawRecord) error {
+	rerun, err := performDraw(record.Entrants, record.Constraints, record.Seed)
+	if err != nil {
+		return err
+	}
+	if len(rerun.Order) != len(record.Order) {
+		return fmt.Errorf("re-run produced %d contestants, record has %d", len(rerun.Order), len(record.Order))
+	}
+	for i := range rerun.Order {
+		if rerun.Order[i] != record.Order[i] {
+			return fmt.Errorf("position %d differs: re-run gives '%s', record has '%s'", i+1, rerun.Order[i], record.Order[i])
+		}
+	}
+	return nil
+}
+
+// applyDraw reorders the contestants to the drawn order. Existing time slots stay
+// in place and are handed out to the contestants in the new order.
+func applyDraw(record *DrawRecord, contestants []*Contestant) ([]*Contestant, error) {
+	byName := make(map[string]*Contestant, len(contestants))
+	for _, contestant := range contestants {
+		byName[contestant.Name] = contestant
+	}
+
+	reordered := make([]*Contestant, 0, len(record.Order))
+	for _, name := range record.Order {
+		contestant, exists := byName[name]
+		if !exists {
+			return nil, fmt.Errorf("drawn contestant '%s' no longer exists", name)
+		}
+		reordered = append(reordered, contestant)
+	}
+	if len(reordered) != len(contestants) {
+		return nil, fmt.Errorf("the draw covers %d of %d contestants", len(reordered), len(contestants))
+	}
+
+	if hasSchedule(contestants) {
+		slots := []*TimeSlot{}
+		for _, contestant := range runningOrder(contestants) {
+			slots = append(slots, contestant.Slot)
+		}
+		for i, contestant := range reordered {
+			contestant.Slot = slots[i]
+		}
+	}
+	return reordered, nil
+}
+
+// checkDrawEntrants validates that the names can be used to identify contestants in a draw
+func checkDrawEntrants(contestants []*Contestant) error {
+	seen := make(map[string]bool)
+	for i, contestant := range contestants {
+		if strings.TrimSpace(contestant.Name) == "" {
+			return fmt.Errorf("Contestant #%d has an empty name.", i+1)
+		}
+		if seen[contestant.Name] {
+			return fmt.Errorf("Contestant name '%s' is used more than once.", contestant.Name)
+		}
+		seen[contestant.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+const noPreviousWinner = "(none)"
+
+// Start Order Draw Window Function
+func showDrawWindow(myApp fyne.App, history *UndoHistory, current *Competition, contestants *[]*Contestant, contestantsTable *widget.Table) {
+	drawWindow := myApp.NewWindow("Start Order Draw")
+	drawWindow.Resize(fyne.NewSize(500, 500))
+
+	contestantsMutex.RLock()
+	names := []string{noPreviousWinner}
+	for _, contestant := range *contestants {
+		names = append(names, contestant.Name)
+	}
+	contestantsMutex.RUnlock()
+
+	// Seed Entry
+	seedEntry := widget.NewEntry()
+	seedEntry.SetText(strconv.FormatInt(newDrawSeed(), 10))
+
+	// Constraints
+	winnerSelect := widget.NewSelect(names, nil)
+	winnerSelect.SetSelected(noPreviousWinner)
+	separateTeamsCheck := widget.NewCheck("Team members not back-to-back", nil)
+
+	if len(current.Draws) > 0 {
+		last := current.Draws[len(current.Draws)-1]
+		if last.Constraints.PreviousWinner != "" {
+			winnerSelect.SetSelected(last.Constraints.PreviousWinner)
+		}
+		separateTeamsCheck.SetChecked(last.Constraints.SeparateTeams)
+	}
+
+	resultLabel := widget.NewLabel("")
+	resultLabel.Wrapping = fyne.TextWrapWord
+
+	var drawn *DrawRecord
+	var applyButton *widget.Button
+
+	drawButton := widget.NewButton("Draw", func() {
+		seed, err := strconv.ParseInt(strings.TrimSpace(seedEntry.Text), 10, 64)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("The seed must be a whole number."), drawWindow)
+			return
+		}
+
+		constraints := DrawConstraints{SeparateTeams: separateTeamsCheck.Checked}
+		if winnerSelect.Selected != noPreviousWinner {
+			constraints.PreviousWinner = winnerSelect.Selected
+		}
+
+		contestantsMutex.RLock()
+		err = checkDrawEntrants(*contestants)
+		entrants := drawEntrants(*contestants)
+		contestantsMutex.RUnlock()
+		if err != nil {
+			dialog.ShowError(err, drawWindow)
+			return
+		}
+
+		record, err := performDraw(entrants, constraints, seed)
+		if err != nil {
+			dialog.ShowError(err, drawWindow)
+			return
+		}
+		record.Timestamp = time.Now().UTC()
+		drawn = record
+
+		resultLabel.SetText(formatDrawRecord(record))
+		applyButton.Enable()
+	})
+
+	applyButton = widget.NewButton("Apply", func() {
+		if drawn == nil {
+			return
+		}
+		contestantsMutex.RLock()
+		reordered, err := applyDraw(drawn, *contestants)
+		contestantsMutex.RUnlock()
+		if err != nil {
+			dialog.ShowError(err, drawWindow)
+			return
+		}
+
+		// Undoing the draw restores the running order and removes the draw from the record
+		reorder := replaceListCommand("Apply Draw", contestants, &contestantsMutex, reordered, contestantsTable.Refresh)
+		draws, record := current.Draws, drawn
+		history.Execute(newCommand("Apply Draw", func() {
+			reorder.Do()
+			current.Draws = append(draws[:len(draws):len(draws)], record)
+		}, func() {
+			reorder.Undo()
+			current.Draws = draws
+		}))
+		drawWindow.Close()
+	})
+	applyButton.Disable()
+
+	// Verify the last recorded draw
+	verifyButton := widget.NewButton("Verify Last Draw", func() {
+		if len(current.Draws) == 0 {
+			dialog.ShowInformation("No Draw", "This competition has no recorded draw.", drawWindow)
+			return
+		}
+		last := current.Draws[len(current.Draws)-1]
+		if err := verifyDraw(last); err != nil {
+			dialog.ShowError(fmt.Errorf("The recorded draw could not be reproduced: %v", err), drawWindow)
+			return
+		}
+		dialog.ShowInformation("Verified", fmt.Sprintf("The draw from %s was reproduced with seed %d.",
+			last.Timestamp.Local().Format(scheduleTimeLayout), last.Seed), drawWindow)
+	})
+
+	lastDrawText := "No draw recorded yet."
+	if len(current.Draws) > 0 {
+		last := current.Draws[len(current.Draws)-1]
+		lastDrawText = fmt.Sprintf("Last draw: %s, seed %d", last.Timestamp.Local().Format(scheduleTimeLayout), last.Seed)
+	}
+
+	drawWindow.SetContent(container.NewBorder(
+		container.NewVBox(
+			widget.NewLabel(lastDrawText),
+			widget.NewLabelWithStyle("Seed:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			container.NewBorder(nil, nil, nil,
+				widget.NewButton("New Seed", func() {
+					seedEntry.SetText(strconv.FormatInt(newDrawSeed(), 10))
+				}),
+				seedEntry,
+			),
+			widget.NewLabelWithStyle("Previous winner (performs last):", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			winnerSelect,
+			separateTeamsCheck,
+			container.NewHBox(drawButton, layout.NewSpacer(), verifyButton),
+			widget.NewSeparator(),
+		),
+		container.NewHBox(
+			layout.NewSpacer(),
+			widget.NewButton("Close", func() {
+				drawWindow.Close()
+			}),
+			applyButton,
+		),
+		nil, nil,
+		container.NewVScroll(resultLabel),
+	))
+	drawWindow.Show()
+}
+
+// formatDrawRecord returns a readable summary of a draw
+func formatDrawRecord(record *DrawRecord) string {
+	var result string
+	result += fmt.Sprintf("Seed %d, drawn %s (attempt %d)\n\n", record.Seed, record.Timestamp.Local().Format(scheduleTimeLayout), record.Attempts)
+	for i, name := range record.Order {
+		result += fmt.Sprintf("%d. %s\n", i+1, name)
+	}
+	return result
+}
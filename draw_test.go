@@ -0,0 +1,157 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPerformDraw(t *testing.T) {
+	plain := []DrawEntrant{{Name: "Anna"}, {Name: "Ben"}, {Name: "Clara"}, {Name: "David"}, {Name: "Eva"}}
+	teams := []DrawEntrant{
+		{Name: "Anna", Team: "Red"}, {Name: "Ben", Team: "red "}, {Name: "Clara", Team: "Blue"},
+		{Name: "David", Team: "Blue"}, {Name: "Eva"},
+	}
+
+	tests := []struct {
+		name        string
+		entrants    []DrawEntrant
+		constraints DrawConstraints
+		seed        int64
+		order       []string // Expected order, if fixed by the seed
+		wantErr     bool
+	}{
+		{name: "fixed seed gives a fixed order", entrants: plain, seed: 42, order: []string{"Clara", "David", "Eva", "Anna", "Ben"}},
+		{name: "another seed gives another order", entrants: plain, seed: 7, order: []string{"Clara", "Ben", "David", "Anna", "Eva"}},
+		{name: "previous winner performs last", entrants: plain, constraints: DrawConstraints{PreviousWinner: "Clara"}, seed: 42},
+		{name: "team members are separated", entrants: teams, constraints: DrawConstraints{SeparateTeams: true}, seed: 42},
+		{name: "both constraints", entrants: teams, constraints: DrawConstraints{PreviousWinner: "Anna", SeparateTeams: true}, seed: 3},
+		{
+			name:        "teams that cannot be separated",
+			entrants:    []DrawEntrant{{Name: "Anna", Team: "Red"}, {Name: "Ben", Team: "Red"}, {Name: "Clara", Team: "Red"}, {Name: "David"}},
+			constraints: DrawConstraints{SeparateTeams: true},
+			seed:        42,
+			wantErr:     true,
+		},
+		{name: "unknown previous winner", entrants: plain, constraints: DrawConstraints{PreviousWinner: "Zoe"}, seed: 42, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record, err := performDraw(test.entrants, test.constraints, test.seed)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("performDraw = %v, want an error", record.Order)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("performDraw: %v", err)
+			}
+
+			if len(record.Order) != len(test.entrants) {
+				t.Fatalf("order has %d contestants, want %d", len(record.Order), len(test.entrants))
+			}
+			if test.order != nil && strings.Join(record.Order, ", ") != strings.Join(test.order, ", ") {
+				t.Errorf("order = %v, want %v", record.Order, test.order)
+			}
+			if winner := test.constraints.PreviousWinner; winner != "" && record.Order[len(record.Order)-1] != winner {
+				t.Errorf("order = %v, want %s last", record.Order, winner)
+			}
+			if test.constraints.SeparateTeams {
+				byName := make(map[string]DrawEntrant)
+				for _, entrant := range test.entrants {
+					byName[entrant.Name] = entrant
+				}
+				order := make([]DrawEntrant, len(record.Order))
+				for i, name := range record.Order {
+					order[i] = byName[name]
+				}
+				if !teamsSeparated(order) {
+					t.Errorf("order = %v puts team members back-to-back", record.Order)
+				}
+			}
+
+			// The same seed always draws the same order
+			again, err := performDraw(test.entrants, test.constraints, test.seed)
+			if err != nil || strings.Join(again.Order, ", ") != strings.Join(record.Order, ", ") {
+				t.Errorf("second draw = %v, %v, want %v", again, err, record.Order)
+			}
+		})
+	}
+}
+
+func TestVerifyDraw(t *testing.T) {
+	entrants := []DrawEntrant{
+		{Name: "Anna", Team: "Red"}, {Name: "Ben", Team: "Red"}, {Name: "Clara"},
+		{Name: "David"}, {Name: "Eva"}, {Name: "Fiona"},
+	}
+	constraints := DrawConstraints{PreviousWinner: "David", SeparateTeams: true}
+	draw := func() *DrawRecord {
+		record, err := performDraw(entrants, constraints, 1234)
+		if err != nil {
+			t.Fatalf("performDraw: %v", err)
+		}
+		return record
+	}
+
+	if err := verifyDraw(draw()); err != nil {
+		t.Errorf("verifyDraw of an untouched record: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(record *DrawRecord)
+	}{
+		{"swapped positions", func(record *DrawRecord) {
+			record.Order[0], record.Order[1] = record.Order[1], record.Order[0]
+		}},
+		{"other seed", func(record *DrawRecord) {
+			record.Seed++
+		}},
+		{"dropped contestant", func(record *DrawRecord) {
+			record.Order = record.Order[:len(record.Order)-1]
+		}},
+		{"other previous winner", func(record *DrawRecord) {
+			record.Constraints.PreviousWinner = "Clara"
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record := draw()
+			test.tamper(record)
+			if err := verifyDraw(record); err == nil {
+				t.Errorf("verifyDraw accepted a record with %s", test.name)
+			}
+		})
+	}
+}
+
+func TestApplyDraw(t *testing.T) {
+	start := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	slot := func(minutes int) *TimeSlot {
+		return &TimeSlot{Start: start.Add(time.Duration(minutes) * time.Minute), Sauna: "Main"}
+	}
+	contestants := []*Contestant{
+		{Name: "Anna", Slot: slot(0)},
+		{Name: "Ben", Slot: slot(30)},
+		{Name: "Clara", Slot: slot(60)},
+	}
+	record := &DrawRecord{Order: []string{"Clara", "Anna", "Ben"}}
+
+	reordered, err := applyDraw(record, contestants)
+	if err != nil {
+		t.Fatalf("applyDraw: %v", err)
+	}
+	// The slots stay in place and go to the contestants in the drawn order
+	for i, minutes := range []int{0, 30, 60} {
+		if reordered[i].Name != record.Order[i] || !reordered[i].Slot.Start.Equal(start.Add(time.Duration(minutes)*time.Minute)) {
+			t.Errorf("place %d: %s at %v, want %s at +%d minutes", i+1, reordered[i].Name, reordered[i].Slot.Start, record.Order[i], minutes)
+		}
+	}
+
+	// A draw naming a contestant who was removed since cannot be applied
+	if _, err := applyDraw(&DrawRecord{Order: []string{"Clara", "Anna", "Zoe"}}, contestants); err == nil {
+		t.Error("applyDraw with an unknown contestant succeeded")
+	}
+}
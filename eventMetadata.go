@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	eventDateLayout = "2006-01-02" // Layout used to enter event dates
+	headerRowCount  = 4            // Rows inserted at the top of each sheet for the event header
+)
+
+// EventMetadata describes the event a competition belongs to
+type EventMetadata struct {
+	StartDate      string `json:"start_date,omitempty"`
+	EndDate        string `json:"end_date,omitempty"`
+	Venue          string `json:"venue,omitempty"`
+	Organizer      string `json:"organizer,omitempty"`
+	Federation     string `json:"federation,omitempty"`
+	RuleSetVersion string `json:"rule_set_version,omitempty"`
+	LogoURL        string `json:"logo_url,omitempty"`
+}
+
+// IsEmpty reports whether no metadata has been entered
+func (m EventMetadata) IsEmpty() bool {
+	return m == EventMetadata{}
+}
+
+// Validate checks the format of the dates and the logo URL
+func (m EventMetadata) Validate() error {
+	var start, end time.Time
+	var err error
+	if m.StartDate != "" {
+		if start, err = time.Parse(eventDateLayout, m.StartDate); err != nil {
+			return fmt.Errorf("Invalid start date '%s'. Use the format %s.", m.StartDate, eventDateLayout)
+		}
+	}
+	if m.EndDate != "" {
+		if end, err = time.Parse(eventDateLayout, m.EndDate); err != nil {
+			return fmt.Errorf("Invalid end date '%s'. Use the format %s.", m.EndDate, eventDateLayout)
+		}
+		if m.StartDate != "" && end.Before(start) {
+			return fmt.Errorf("The end date is before the start date.")
+		}
+	}
+	if m.LogoURL != "" && !strings.HasPrefix(m.LogoURL, "https://") && !strings.HasPrefix(m.LogoURL, "http://") {
+		return fmt.Errorf("The logo URL must start with http:// or https://.")
+	}
+	return nil
+}
+
+// Dates returns the event dates as a single readable string
+func (m EventMetadata) Dates() string {
+	switch {
+	case m.StartDate == "":
+		return m.EndDate
+	case m.EndDate == "" || m.EndDate == m.StartDate:
+		return m.StartDate
+	default:
+		return fmt.Sprintf("%s – %s", m.StartDate, m.EndDate)
+	}
+}
+
+// headerLines returns the lines shown below the competition name in the sheet header
+func (m EventMetadata) headerLines() []string {
+	lines := []string{}
+	if where := joinNonEmpty(" · ", m.Dates(), m.Venue); where != "" {
+		lines = append(lines, where)
+	}
+	details := joinNonEmpty(" · ",
+		prefixNonEmpty("Organizer: ", m.Organizer),
+		prefixNonEmpty("Federation: ", m.Federation),
+		prefixNonEmpty("Rules: ", m.RuleSetVersion),
+	)
+	if details != "" {
+		lines = append(lines, details)
+	}
+	return lines
+}
+
+func joinNonEmpty(separator string, parts ...string) string {
+	nonEmpty := []string{}
+	for _, part := range parts {
+		if strings.TrimSpace(part) != "" {
+			nonEmpty = append(nonEmpty, strings.TrimSpace(part))
+		}
+	}
+	return strings.Join(nonEmpty, separator)
+}
+
+func prefixNonEmpty(prefix, value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	return prefix + strings.TrimSpace(value)
+}
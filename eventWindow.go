@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Event Details Window Function
+func showEventDetails(myApp fyne.App, history *UndoHistory, metadata *EventMetadata) {
+	eventWindow := myApp.NewWindow("Event Details")
+	eventWindow.Resize(fyne.NewSize(500, 400))
+
+	newEntry := func(value, placeHolder string) *widget.Entry {
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder(placeHolder)
+		entry.SetText(value)
+		return entry
+	}
+
+	startDateEntry := newEntry(metadata.StartDate, eventDateLayout)
+	endDateEntry := newEntry(metadata.EndDate, eventDateLayout)
+	venueEntry := newEntry(metadata.Venue, "Venue")
+	organizerEntry := newEntry(metadata.Organizer, "Organizer")
+	federationEntry := newEntry(metadata.Federation, "Federation")
+	ruleSetEntry := newEntry(metadata.RuleSetVersion, "e.g. 2025.1")
+	logoURLEntry := newEntry(metadata.LogoURL, "https://")
+
+	form := widget.NewForm(
+		widget.NewFormItem("Start date", startDateEntry),
+		widget.NewFormItem("End date", endDateEntry),
+		widget.NewFormItem("Venue", venueEntry),
+		widget.NewFormItem("Organizer", organizerEntry),
+		widget.NewFormItem("Federation", federationEntry),
+		widget.NewFormItem("Rule set version", ruleSetEntry),
+		widget.NewFormItem("Logo URL", logoURLEntry),
+	)
+
+	// Save Button
+	saveButton := widget.NewButton("OK", func() {
+		updated := EventMetadata{
+			StartDate:      strings.TrimSpace(startDateEntry.Text),
+			EndDate:        strings.TrimSpace(endDateEntry.Text),
+			Venue:          strings.TrimSpace(venueEntry.Text),
+			Organizer:      strings.TrimSpace(organizerEntry.Text),
+			Federation:     strings.TrimSpace(federationEntry.Text),
+			RuleSetVersion: strings.TrimSpace(ruleSetEntry.Text),
+			LogoURL:        strings.TrimSpace(logoURLEntry.Text),
+		}
+		if err := updated.Validate(); err != nil {
+			dialog.ShowError(err, eventWindow)
+			return
+		}
+		previous := *metadata
+		if updated != previous {
+			history.Execute(newCommand("Edit Event Details", func() {
+				*metadata = updated
+			}, func() {
+				*metadata = previous
+			}))
+		}
+		eventWindow.Close()
+	})
+
+	eventWindow.SetContent(container.NewVBox(
+		widget.NewLabelWithStyle("Event details shown in the header of the generated sheets:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		form,
+		container.NewHBox(
+			layout.NewSpacer(),
+			widget.NewButton("Cancel", func() {
+				eventWindow.Close()
+			}),
+			saveButton,
+		),
+	))
+	eventWindow.Show()
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FeedbackBooklet collects what the jury scored and wrote for one contestant
+type FeedbackBooklet struct {
+	Contestant string
+	Team       string
+	Rank       int // 0 if the contestant is not ranked
+	Ranked     int // Number of ranked contestants
+	Total      *float64
+	Jurors     []JurorFeedback
+}
+
+// JurorFeedback is the part of a booklet from one juror
+type JurorFeedback struct {
+	Juror    string
+	Points   []LabeledPoints
+	Total    *float64
+	Feedback []string
+	Missing  bool // The juror's scores could not be read or are empty
+}
+
+// LabeledPoints are the points a juror gave for one label of the template
+type LabeledPoints struct {
+	Label  string
+	Points *float64
+}
+
+// feedbackBooklets compiles one booklet per contestant in running order. With anonymize set,
+// jurors are named by their position in the jury instead of their name.
+func feedbackBooklets(comp Competition, results *CompetitionResults, standings *Standings, anonymize bool) []FeedbackBooklet {
+	var booklets []FeedbackBooklet
+	for _, tab := range contestantTabs(comp, results) {
+		booklet := FeedbackBooklet{Contestant: tab.Contestant, Team: contestantTeam(comp, tab.Contestant)}
+		if standings != nil {
+			booklet.Ranked = len(standings.Results)
+			for _, result := range standings.Results {
+				if result.Contestant == tab.Contestant {
+					booklet.Rank = result.Rank
+					total := result.Total
+					booklet.Total = &total
+				}
+			}
+		}
+
+		for j, juror := range results.Jurors {
+			feedback := JurorFeedback{Juror: juror.Juror}
+			if anonymize {
+				feedback.Juror = fmt.Sprintf("Juror %d", j+1)
+			}
+			scores, ok := results.Scores(j, tab.Contestant)
+			if total, scored := scores.Total(); ok && scored {
+				feedback.Total = &total
+			}
+			for _, line := range scores.Rows {
+				for i, points := range line.Points {
+					label := strings.TrimSuffix(strings.TrimSpace(line.Labels[i]), ":")
+					if label == "" && points == nil {
+						continue
+					}
+					if label == "" {
+						label = fmt.Sprintf("#%d", i+1)
+					}
+					feedback.Points = append(feedback.Points, LabeledPoints{Label: label, Points: points})
+				}
+			}
+			feedback.Feedback = scores.Feedback()
+			feedback.Missing = juror.Error != "" || feedback.Total == nil && len(feedback.Feedback) == 0
+			booklet.Jurors = append(booklet.Jurors, feedback)
+		}
+		booklets = append(booklets, booklet)
+	}
+	return booklets
+}
+
+// bookletFileName returns the file name of a booklet, numbered in running order
+func bookletFileName(index int, booklet FeedbackBooklet, extension string) string {
+	return fmt.Sprintf("%02d - %s.%s", index+1, sanitizeFileName(booklet.Contestant), extension)
+}
+
+// bookletPlacing describes the placing and total of a booklet, or "" if it is not ranked
+func bookletPlacing(booklet FeedbackBooklet, standings *Standings) string {
+	if booklet.Rank == 0 || booklet.Total == nil {
+		return ""
+	}
+	return fmt.Sprintf("Placing: %d of %d · %s: %s", booklet.Rank, booklet.Ranked, standings.totalLabel(), formatScore(*booklet.Total))
+}
+
+// bookletMarkdown renders a booklet as Markdown
+func bookletMarkdown(comp Competition, booklet FeedbackBooklet, standings *Standings) []byte {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "# Jury Feedback - %s\n\n", booklet.Contestant)
+	fmt.Fprintf(&builder, "%s\n\n", comp.Name)
+	for _, line := range comp.Event.headerLines() {
+		fmt.Fprintf(&builder, "%s  \n", line)
+	}
+	if booklet.Team != "" {
+		fmt.Fprintf(&builder, "Team: %s  \n", booklet.Team)
+	}
+	if placing := bookletPlacing(booklet, standings); placing != "" {
+		fmt.Fprintf(&builder, "%s  \n", placing)
+	}
+
+	for _, juror := range booklet.Jurors {
+		fmt.Fprintf(&builder, "\n## %s\n\n", juror.Juror)
+		if juror.Missing {
+			builder.WriteString("No scores or feedback.\n")
+			continue
+		}
+		if len(juror.Points) > 0 {
+			builder.WriteString("| Criterion | Points |\n|---|---|\n")
+			for _, points := range juror.Points {
+				fmt.Fprintf(&builder, "| %s | %s |\n", markdownCell(points.Label), formatOptionalScore(points.Points))
+			}
+			if juror.Total != nil {
+				fmt.Fprintf(&builder, "| **Total** | **%s** |\n", formatScore(*juror.Total))
+			}
+		}
+		if len(juror.Feedback) > 0 {
+			builder.WriteString("\n")
+			for _, text := range juror.Feedback {
+				for _, line := range strings.Split(text, "\n") {
+					fmt.Fprintf(&builder, "> %s\n", line)
+				}
+				builder.WriteString(">\n")
+			}
+		}
+	}
+	return []byte(builder.String())
+}
+
+// bookletPDF renders a booklet as PDF
+func bookletPDF(comp Competition, booklet FeedbackBooklet, standings *Standings) ([]byte, error) {
+	document := newPDFDocument(fmt.Sprintf("%s - Jury Feedback - %s", comp.Name, booklet.Contestant))
+	if err := pdfHeader(document, comp, fmt.Sprintf("Jury Feedback for %s", booklet.Contestant)); err != nil {
+		return nil, err
+	}
+	if booklet.Team != "" {
+		document.paragraph(fmt.Sprintf("Team: %s", booklet.Team), 10, false)
+	}
+	if placing := bookletPlacing(booklet, standings); placing != "" {
+		document.paragraph(placing, 10, false)
+	}
+
+	for _, juror := range booklet.Jurors {
+		document.heading(juror.Juror, 12)
+		if juror.Missing {
+			document.paragraph("No scores or feedback.", 10, false)
+			continue
+		}
+		if len(juror.Points) > 0 {
+			var rows [][]string
+			for _, points := range juror.Points {
+				rows = append(rows, []string{points.Label, formatOptionalScore(points.Points)})
+			}
+			if juror.Total != nil {
+				rows = append(rows, []string{"Total", formatScore(*juror.Total)})
+			}
+			document.table([]pdfColumn{
+				{Title: "Criterion", Width: 250},
+				{Title: "Points", Width: 70, Right: true},
+			}, rows, 10)
+		}
+		for _, text := range juror.Feedback {
+			document.space(4)
+			document.paragraph(text, 10, false)
+		}
+	}
+	return document.bytes()
+}
+
+// formatOptionalScore formats a score, or "-" if there is none
+func formatOptionalScore(score *float64) string {
+	if score == nil {
+		return "-"
+	}
+	return formatScore(*score)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	log "github.com/s00500/env_logger"
+)
+
+// Booklet formats offered by the export, in menu order
+const (
+	bookletFormatBoth     = "PDF and Markdown"
+	bookletFormatPDF      = "PDF"
+	bookletFormatMarkdown = "Markdown"
+)
+
+// Feedback Booklets Export Function
+func showFeedbackExport(parent fyne.Window, comp Competition, results *CompetitionResults, standings *Standings) {
+	anonymizeCheck := widget.NewCheck("Anonymize juror names", nil)
+	placingCheck := widget.NewCheck("Include placing and total", nil)
+	placingCheck.SetChecked(true)
+	formatSelect := widget.NewSelect([]string{bookletFormatBoth, bookletFormatPDF, bookletFormatMarkdown}, nil)
+	formatSelect.SetSelected(bookletFormatBoth)
+
+	hint := widget.NewLabel("One file per contestant is written to the chosen folder, with every juror's points and feedback.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		hint,
+		container.NewBorder(nil, nil, widget.NewLabel("Format:"), nil, formatSelect),
+		anonymizeCheck,
+		placingCheck,
+	)
+	exportDialog := dialog.NewCustomConfirm("Feedback Booklets", "Export to Folder...", "Cancel", content, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		folderDialog := dialog.NewFolderOpen(func(folder fyne.ListableURI, err error) {
+			if err != nil || folder == nil {
+				if err != nil {
+					log.Printf("Folder selection error: %v", err)
+				}
+				return
+			}
+			ranking := standings
+			if !placingCheck.Checked {
+				ranking = nil
+			}
+			count, err := exportFeedbackBooklets(folder, comp, feedbackBooklets(comp, results, ranking, anonymizeCheck.Checked), ranking, formatSelect.Selected)
+			if err != nil {
+				dialog.ShowError(err, parent)
+				return
+			}
+			dialog.ShowInformation("Feedback Booklets", fmt.Sprintf("%d file(s) written to %s.", count, folder.Path()), parent)
+		}, parent)
+		folderDialog.Show()
+	}, parent)
+	exportDialog.Resize(fyne.NewSize(450, 250))
+	exportDialog.Show()
+}
+
+// exportFeedbackBooklets writes the booklets to a folder and returns the number of files written
+func exportFeedbackBooklets(folder fyne.ListableURI, comp Competition, booklets []FeedbackBooklet, standings *Standings, format string) (int, error) {
+	count := 0
+	write := func(name string, data []byte) error {
+		uri, err := storage.Child(folder, name)
+		if err != nil {
+			return fmt.Errorf("Failed to create %s: %w", name, err)
+		}
+		writer, err := storage.Writer(uri)
+		if err != nil {
+			return fmt.Errorf("Failed to create %s: %w", name, err)
+		}
+		defer writer.Close()
+		if _, err := writer.Write(data); err != nil {
+			return fmt.Errorf("Failed to write %s: %w", name, err)
+		}
+		count++
+		return nil
+	}
+
+	for i, booklet := range booklets {
+		if format != bookletFormatMarkdown {
+			data, err := bookletPDF(comp, booklet, standings)
+			if err != nil {
+				return count, err
+			}
+			if err := write(bookletFileName(i, booklet, "pdf"), data); err != nil {
+				return count, err
+			}
+		}
+		if format != bookletFormatPDF {
+			if err := write(bookletFileName(i, booklet, "md"), bookletMarkdown(comp, booklet, standings)); err != nil {
+				return count, err
+			}
+		}
+	}
+	return count, nil
+}
@@ -1,10 +1,8 @@
 package main
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
-	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -26,29 +24,65 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
+// layoutActions exposes actions of the main layout to the menu and the startup code
+type layoutActions struct {
+	RefreshCompetitions func() // Reloads the competition list from the store
+	History             *UndoHistory
+	SaveDraft           func() // Autosaves unsaved changes of the open competition
+	RecoverDrafts       func() // Offers to recover drafts left by an earlier session
+}
+
+func fynelayout(myWindow fyne.Window, myApp fyne.App) (*fyne.Container, *layoutActions) {
 	// Declare the fileMap and its mutex in the main scope
 	fileMap := make(map[string]string)
 	var fileMapMutex sync.RWMutex
 
+	// Undo history of the edits to the open competition
+	history := newUndoHistory()
+
 	// Competition Name
-	nameEntry := widget.NewEntry()
+	nameEntry := newHistoryEntry(history)
 	nameEntry.SetPlaceHolder("Enter Competition Name")
+	lastName := ""
+	nameEntry.OnChanged = func(text string) {
+		oldName := lastName
+		lastName = text
+		history.Record(&fieldEdit{key: "name", name: "Competition Name", oldValue: oldName, newValue: text, typing: true, apply: func(value string) {
+			lastName = value
+			nameEntry.SetText(value)
+		}})
+	}
 
 	// Jurors Slice
 	jurors := []*Juror{}
 
 	// Create Jury Table
-	juryTableComposition, juryTable := createJuryTable(&jurors)
+	juryTableComposition, juryTable, updateWeightSum := createJuryTable(&jurors, history)
 
 	// Contestants Slice
 	contestants := []*Contestant{}
 
+	// Settings of the loaded competition that are not bound to the widgets above
+	current := &Competition{}
+
 	// Create Contestants Table
-	contestantTableComposition, contestantTable := createContestantsTable(&contestants)
+	contestantTableComposition, contestantTable := createContestantsTable(&contestants, history)
 
 	// Create Template Sheet Selector
 	templateSheetSelectorContainer, templateSheetSelect := createTemplateSheetSelector(myApp, &fileMap, &fileMapMutex)
+	lastTemplate := ""
+	templateSheetSelect.OnChanged = func(selected string) {
+		oldTemplate := lastTemplate
+		lastTemplate = selected
+		history.Record(&fieldEdit{key: "template", name: "Template", oldValue: oldTemplate, newValue: selected, apply: func(value string) {
+			lastTemplate = value
+			if value == "" {
+				templateSheetSelect.ClearSelected()
+			} else {
+				templateSheetSelect.SetSelected(value)
+			}
+		}})
+	}
 
 	// Create a read-only log field with black text
 	logField := NewCustomLogField(color.Black)
@@ -57,22 +91,363 @@ func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
 	scrollableLog := container.NewVScroll(logField)
 	scrollableLog.SetMinSize(fyne.NewSize(400, 150)) // Set a minimum size for the log window
 
+	// buildCurrentCompetition collects the competition from the widgets
+	buildCurrentCompetition := func() Competition {
+		fileMapMutex.RLock()
+		defer fileMapMutex.RUnlock()
+		var sheetId string
+		for name, id := range fileMap {
+			if name == templateSheetSelect.Selected {
+				sheetId = id
+				break
+			}
+		}
+		return buildCompetition(current, strings.TrimSpace(nameEntry.Text), sheetId, jurors, contestants)
+	}
+
 	var right, left *fyne.Container
 
-	// Dropdown for loading competitions
-	files, err := loadCompetitionFiles()
-	if err != nil {
-		log.Fatalf("Failed to load files: %v", err)
+	// Dropdown for loading competitions, showing names mapped to their IDs
+	competitionIDs := make(map[string]string)
+	var fileSelect *widget.Select
+	var openedOption string // fileSelect option of the open competition
+	var openedID string     // ID of the open competition, empty if it was never saved
+
+	// Modification time of the open competition when this window last loaded or saved it
+	var knownModified time.Time
+
+	// The store watcher updates the list from its own goroutine, so the list and what it
+	// knows about the open competition are guarded by listMutex
+	var listMutex sync.Mutex
+	lookupCompetitionID := func(option string) (string, bool) {
+		listMutex.Lock()
+		defer listMutex.Unlock()
+		id, exists := competitionIDs[option]
+		return id, exists
+	}
+	getKnownModified := func() time.Time {
+		listMutex.Lock()
+		defer listMutex.Unlock()
+		return knownModified
+	}
+	setKnownModified := func(id string) {
+		modified := storedModified(id)
+		listMutex.Lock()
+		knownModified = modified
+		listMutex.Unlock()
+	}
+
+	// Unsaved changes are found by comparing the competition with the state it was opened in.
+	// While there are any, a draft is autosaved under draftKey, which is the competition's ID
+	// or, for competitions that were never saved, a key of its own. Only drafts this session
+	// wrote or recovered are removed, so drafts left by a crash are kept until recovered.
+	var openedSnapshot []byte
+	var draftKey string
+	ownDrafts := make(map[string]bool)
+	var ownDraftsMutex sync.Mutex // Autosaving runs on a ticker goroutine
+
+	// The autosave ticker must not read the widgets or current, which the UI changes without
+	// locking. noteDraft hands it the encoded state instead, after every edit and whenever the
+	// open competition or its saved state changes. pendingDraftMutex is held while a draft is
+	// written or removed, so a draft of changes that were just saved cannot be written after.
+	type draftState struct {
+		key  string
+		data []byte // Encoded competition, nil if there are no unsaved changes
+	}
+	var pendingDraft draftState
+	var pendingDraftMutex sync.Mutex
+	isOwnDraft := func(key string) bool {
+		ownDraftsMutex.Lock()
+		defer ownDraftsMutex.Unlock()
+		return ownDrafts[key]
+	}
+	setOwnDraft := func(key string, own bool) {
+		ownDraftsMutex.Lock()
+		defer ownDraftsMutex.Unlock()
+		if own {
+			ownDrafts[key] = true
+		} else {
+			delete(ownDrafts, key)
+		}
+	}
+	snapshot := func() []byte {
+		comp := buildCurrentCompetition()
+		jurorsMutex.RLock()
+		defer jurorsMutex.RUnlock()
+		contestantsMutex.RLock()
+		defer contestantsMutex.RUnlock()
+		data, _ := encodeCompetition(comp)
+		return data
+	}
+	hasUnsavedChanges := func() bool {
+		return draftKey != "" && !bytes.Equal(snapshot(), openedSnapshot)
+	}
+	noteDraft := func() {
+		state := draftState{key: draftKey}
+		if data := snapshot(); draftKey != "" && !bytes.Equal(data, openedSnapshot) {
+			state.data = data
+		}
+		pendingDraftMutex.Lock()
+		pendingDraft = state
+		pendingDraftMutex.Unlock()
+	}
+	removeOwnDraft := func(key string) { // pendingDraftMutex must be held
+		if key == "" || !isOwnDraft(key) {
+			return
+		}
+		if err := removeDraft(key); err != nil {
+			log.Printf("Failed to remove draft: %v", err)
+			return
+		}
+		setOwnDraft(key, false)
+	}
+	discardDraft := func() {
+		pendingDraftMutex.Lock()
+		defer pendingDraftMutex.Unlock()
+		pendingDraft = draftState{}
+		removeOwnDraft(draftKey)
+	}
+	autosaveDraft := func() {
+		pendingDraftMutex.Lock()
+		defer pendingDraftMutex.Unlock()
+		state := pendingDraft
+		if state.data == nil {
+			removeOwnDraft(state.key)
+			return
+		}
+		if err := writeDraft(state.key, state.data); err != nil {
+			log.Printf("Failed to autosave draft: %v", err)
+			return
+		}
+		setOwnDraft(state.key, true)
+	}
+	history.OnChanged = noteDraft
+
+	// selectOption selects a competition on behalf of the window, e.g. after saving, so the
+	// unsaved changes prompt is skipped
+	programmaticSelect := false
+	selectOption := func(option string) {
+		programmaticSelect = true
+		defer func() { programmaticSelect = false }()
+		fileSelect.SetSelected(option)
+	}
+
+	// refreshFileSelect reloads the competition list and selects the competition with selectID, if any
+	refreshFileSelect := func(selectID string) {
+		infos, err := store.List()
+		if err != nil {
+			log.Printf("Failed to load competitions: %v", err)
+		}
+		options, ids := competitionOptions(infos)
+		listMutex.Lock()
+		competitionIDs = ids
+		fileSelect.Options = append(options, createNewOption)
+		listMutex.Unlock()
+		fileSelect.Refresh()
+		for option, id := range ids {
+			if id == selectID {
+				selectOption(option)
+				break
+			}
+		}
+	}
+
+	// updateFileSelect reloads the competition list and keeps the open competition selected
+	// without reopening it, so unsaved edits are kept
+	updateFileSelect := func() {
+		infos, err := store.List()
+		if err != nil {
+			log.Printf("Failed to load competitions: %v", err)
+			return
+		}
+		options, ids := competitionOptions(infos)
+		listMutex.Lock()
+		competitionIDs = ids
+		fileSelect.Options = append(options, createNewOption)
+		for option, id := range ids {
+			if id == openedID && openedID != "" {
+				fileSelect.Selected = option
+				openedOption = option
+			}
+		}
+		listMutex.Unlock()
+		fileSelect.Refresh()
+	}
+
+	// markSaved records that the widgets were just stored as the open competition. Unlike
+	// selecting it again, it keeps the undo history.
+	markSaved := func() {
+		discardDraft()
+		listMutex.Lock()
+		openedID = current.ID
+		listMutex.Unlock()
+		updateFileSelect()
+		openedSnapshot = snapshot()
+		draftKey = current.ID
+		if draftExists(draftKey) && !isOwnDraft(draftKey) {
+			draftKey = newCompetitionID()
+		}
+		setKnownModified(current.ID)
+		noteDraft()
 	}
-	files = append(files, "[Create New]")
 
-	fileSelect := widget.NewSelect(files, func(selected string) {
-		if selected != "" {
-			loadCompetition(selected, nameEntry, templateSheetSelect, &jurors, &contestants, fileMap, &fileMapMutex, juryTable, contestantTable)
-			right.Show()
-			left.Show()
+	// confirmDiscardChanges runs discard right away, or after confirmation if the open
+	// competition has unsaved changes, whose draft is then removed
+	confirmDiscardChanges := func(discard func()) {
+		if !hasUnsavedChanges() {
+			discard()
+			return
 		}
+		dialog.ShowConfirm("Unsaved Changes",
+			fmt.Sprintf("'%s' has unsaved changes.\n\nDiscard them?", strings.TrimSpace(nameEntry.Text)),
+			func(confirm bool) {
+				if confirm {
+					discardDraft()
+					draftKey = ""
+					discard()
+				}
+			},
+			myWindow,
+		)
+	}
+
+	// Competitions saved before the juror weights had to add up to 100% cannot be generated
+	// as they are. Normalizing them is offered once per competition and session.
+	weightsOffered := make(map[string]bool)
+	offerWeightNormalization := func() {
+		jurorsMutex.RLock()
+		total, count := sumJurorWeights(jurors), len(jurors)
+		jurorsMutex.RUnlock()
+		if current.ID == "" || count == 0 || total == 100 || weightsOffered[current.ID] {
+			return
+		}
+		weightsOffered[current.ID] = true
+		dialog.ShowConfirm("Juror Weights",
+			fmt.Sprintf("The juror weights of '%s' add up to %d%%, but must add up to 100%% to generate the spreadsheets.\n\nNormalize them now? This can be undone and is kept once the competition is saved.", strings.TrimSpace(nameEntry.Text), total),
+			func(confirm bool) {
+				if confirm {
+					history.Execute(normalizeJurorWeights(&jurors, func() {
+						juryTable.Refresh()
+						updateWeightSum()
+					}))
+				}
+			},
+			myWindow,
+		)
+	}
+
+	// openCompetition loads the competition behind a fileSelect option into the widgets
+	openCompetition := func(selected string) {
+		id := selected
+		if selected != createNewOption {
+			id, _ = lookupCompetitionID(selected)
+		}
+		if programmaticSelect || !hasUnsavedChanges() {
+			// The changes were saved or the competition deleted, so its draft is obsolete
+			discardDraft()
+		}
+		draftKey = "" // Until it is loaded, nothing is autosaved
+
+		var err error
+		history.Suspend(func() {
+			err = loadCompetition(id, current, &nameEntry.Entry, templateSheetSelect, &jurors, &contestants, fileMap, &fileMapMutex, juryTable, contestantTable)
+		})
+		lastName, lastTemplate = nameEntry.Text, templateSheetSelect.Selected
+		history.Clear()
+		if err != nil {
+			draftKey = ""
+			dialog.ShowError(fmt.Errorf("Could not open '%s': %w", selected, err), myWindow)
+			right.Hide()
+			left.Hide()
+			return
+		}
+		if selected != createNewOption && id != current.ID {
+			// The competition was migrated and is now stored under its ID
+			refreshFileSelect(current.ID)
+			return
+		}
+		listMutex.Lock()
+		openedOption, openedID = selected, current.ID
+		listMutex.Unlock()
+		openedSnapshot = snapshot()
+		draftKey = current.ID
+		if draftKey == "" || draftExists(draftKey) && !isOwnDraft(draftKey) {
+			// A draft left by an earlier session keeps its key until it is recovered
+			draftKey = newCompetitionID()
+		}
+		setKnownModified(current.ID)
+		noteDraft()
+		updateWeightSum()
+		right.Show()
+		left.Show()
+		offerWeightNormalization()
+	}
+
+	// showUnsavedCompetition puts a competition into the widgets without reopening it, so it
+	// differs from the opened state and counts as unsaved changes
+	showUnsavedCompetition := func(comp Competition) {
+		history.Suspend(func() {
+			populateCompetition(comp, current, &nameEntry.Entry, templateSheetSelect, &jurors, &contestants, fileMap, &fileMapMutex, juryTable, contestantTable)
+		})
+		lastName, lastTemplate = nameEntry.Text, templateSheetSelect.Selected
+		history.Clear()
+		updateWeightSum()
+		right.Show()
+		left.Show()
+	}
+
+	fileSelect = widget.NewSelect([]string{}, func(selected string) {
+		if selected == "" {
+			return
+		}
+		if programmaticSelect || !hasUnsavedChanges() {
+			openCompetition(selected)
+			return
+		}
+
+		// Keep the open competition selected until the user decided about the unsaved changes
+		listMutex.Lock()
+		fileSelect.Selected = openedOption
+		listMutex.Unlock()
+		fileSelect.Refresh()
+		confirmDiscardChanges(func() {
+			selectOption(selected)
+		})
 	})
+	if _, err := store.List(); err != nil {
+		log.Fatalf("Failed to load competitions: %v", err)
+	}
+	refreshFileSelect("")
+
+	// confirmOverwrite runs save right away, or after confirmation if the stored competition
+	// was changed outside this window since it was opened, e.g. by a synced folder
+	confirmOverwrite := func(save func()) {
+		modified := storedModified(current.ID)
+		if modified.IsZero() || !modified.After(getKnownModified()) {
+			save()
+			return
+		}
+		dialog.ShowConfirm("Competition Changed",
+			fmt.Sprintf("'%s' was changed outside this window after it was opened, for example by another instance of the app or a synced folder.\n\nOverwrite those changes?", strings.TrimSpace(nameEntry.Text)),
+			func(confirm bool) {
+				if confirm {
+					save()
+				}
+			},
+			myWindow,
+		)
+	}
+
+	// saveAsNew stores a copy of the competition under a new ID and opens it
+	saveAsNew := func(comp Competition, name string) {
+		comp.ID = newCompetitionID()
+		comp.Name = strings.TrimSpace(name)
+		if err := store.Save(comp); err != nil {
+			dialog.ShowError(err, myWindow)
+			return
+		}
+		refreshFileSelect(comp.ID)
+	}
 
 	// Save button
 	saveButton := widget.NewButton("Save", func() {
@@ -82,25 +457,19 @@ func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
 			return
 		}
 
-		fileMapMutex.RLock()
-		defer fileMapMutex.RUnlock()
-		var sheetId string
-		for name, id := range fileMap {
-			if name == templateSheetSelect.Selected {
-				sheetId = id
-				break
-			}
-		}
-
-		competition := buildCompetition(strings.TrimSpace(nameEntry.Text), sheetId, jurors, contestants)
-		if err := saveCompetition(competition); err != nil {
-			dialog.ShowError(err, myWindow)
-		} else {
-			dialog.ShowInformation("Success", "Competition saved successfully", myWindow)
-			files, _ := loadCompetitionFiles() // Reload files
-			fileSelect.Options = files
-			fileSelect.SetSelected(fmt.Sprintf("%s.json", competition.Name))
+		competition := buildCurrentCompetition()
+		if competition.ID == "" {
+			competition.ID = newCompetitionID()
+			current.ID = competition.ID
 		}
+		confirmOverwrite(func() {
+			if err := store.Save(competition); err != nil {
+				dialog.ShowError(err, myWindow)
+			} else {
+				dialog.ShowInformation("Success", "Competition saved successfully", myWindow)
+				markSaved()
+			}
+		})
 	})
 
 	var cancelFunc context.CancelFunc
@@ -113,17 +482,7 @@ func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
 			return
 		}
 
-		fileMapMutex.RLock()
-		defer fileMapMutex.RUnlock()
-		var sheetId string
-		for name, id := range fileMap {
-			if name == templateSheetSelect.Selected {
-				sheetId = id
-				break
-			}
-		}
-
-		competition := buildCompetition(strings.TrimSpace(nameEntry.Text), sheetId, jurors, contestants)
+		competition := buildCurrentCompetition()
 
 		// Validate competition fields
 		if err := validateCompetition(competition); err != nil {
@@ -131,64 +490,94 @@ func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
 			return
 		}
 
-		if err := saveCompetition(competition); err != nil {
-			dialog.ShowError(err, myWindow)
-		} else {
-			cancelButton.Enable()
-			generateButton.Disable()
-
-			// Reload files
-			files, _ := loadCompetitionFiles()
-			fileSelect.Options = files
-			fileSelect.SetSelected(fmt.Sprintf("%s.json", competition.Name))
-
-			logField.SetText("Generating...\n")
-			logFunction := func(message string) {
-				logField.SetText(fmt.Sprintf("%s%s", logField.Text, message))
-			}
+		if competition.ID == "" {
+			competition.ID = newCompetitionID()
+			current.ID = competition.ID
+		}
+		confirmOverwrite(func() {
+			if err := store.Save(competition); err != nil {
+				dialog.ShowError(err, myWindow)
+			} else {
+				cancelButton.Enable()
+				generateButton.Disable()
+
+				markSaved()
+
+				logField.SetText("Generating...\n")
+				logFunction := func(message string) {
+					logField.SetText(fmt.Sprintf("%s%s", logField.Text, message))
+				}
 
-			// Create a context with cancellation
-			ctx, cancel := context.WithCancel(context.Background())
-			cancelFunc = cancel
+				// Create a context with cancellation
+				ctx, cancel := context.WithCancel(context.Background())
+				cancelFunc = cancel
 
-			// Channel to signal completion of the goroutine
-			done := make(chan bool)
+				// Channel to signal completion of the goroutine
+				done := make(chan bool)
 
-			// Run the sheet generation asynchronously
-			go func() {
-				err := generateGoogleSheets(ctx, myApp.Preferences().String("credentials"), myApp.Preferences().String("folder_id"), competition, logFunction)
-				if err != nil {
-					logFunction(fmt.Sprintf("Error: %v\n", err))
-				} else {
-					logFunction("Generation completed successfully.\n")
-				}
-				done <- true
-			}()
-
-			// Start a goroutine to update the button label with the elapsed time
-			go func() {
-				startTime := time.Now()
-				ticker := time.NewTicker(1 * time.Second)
-				defer ticker.Stop()
-
-				for {
-					select {
-					case <-ticker.C:
-						// Update the button label with the elapsed time
-						elapsed := time.Since(startTime)
-						minutes := int(elapsed.Minutes())
-						seconds := int(elapsed.Seconds()) % 60
-						generateButton.SetText(fmt.Sprintf("Generate! (%d:%02d)", minutes, seconds))
-					case <-done:
-						// Reset the button label once the process is complete
-						generateButton.SetText("Generate!")
-						generateButton.Enable()
-						cancelButton.Disable()
-						return
+				// Run the sheet generation asynchronously
+				go func() {
+					manifest, err := generateGoogleSheets(ctx, myApp.Preferences().String("credentials"), myApp.Preferences().String("folder_id"), competition, logFunction)
+					if err != nil {
+						logFunction(fmt.Sprintf("Error: %v\n", err))
+					} else {
+						logFunction("Generation completed successfully.\n")
+						recorded := true
+						if err := recordGeneration(competition.ID, manifest); err != nil {
+							logFunction(fmt.Sprintf("Failed to save the generation manifest: %v\n", err))
+							recorded = false
+						}
+						listMutex.Lock()
+						open := openedID == competition.ID
+						listMutex.Unlock()
+						if open && recorded {
+							setKnownModified(competition.ID)
+						}
+
+						// The manifest is applied to the open competition on the UI thread, once
+						// the outcome was shown. If it was stored, the competition stays clean.
+						completed := dialog.NewInformation("Generate", "The spreadsheets were generated.", myWindow)
+						completed.SetOnClosed(func() {
+							if current.ID != competition.ID {
+								return
+							}
+							clean := !hasUnsavedChanges()
+							current.Generation = manifest
+							if clean && recorded {
+								openedSnapshot = snapshot()
+							}
+							noteDraft()
+						})
+						completed.Show()
 					}
-				}
-			}()
-		}
+					done <- true
+				}()
+
+				// Start a goroutine to update the button label with the elapsed time
+				go func() {
+					startTime := time.Now()
+					ticker := time.NewTicker(1 * time.Second)
+					defer ticker.Stop()
+
+					for {
+						select {
+						case <-ticker.C:
+							// Update the button label with the elapsed time
+							elapsed := time.Since(startTime)
+							minutes := int(elapsed.Minutes())
+							seconds := int(elapsed.Seconds()) % 60
+							generateButton.SetText(fmt.Sprintf("Generate! (%d:%02d)", minutes, seconds))
+						case <-done:
+							// Reset the button label once the process is complete
+							generateButton.SetText("Generate!")
+							generateButton.Enable()
+							cancelButton.Disable()
+							return
+						}
+					}
+				}()
+			}
+		})
 	})
 
 	cancelButton = widget.NewButton("Cancel", func() {
@@ -198,74 +587,55 @@ func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
 
 	// Delete button
 	deleteButton := widget.NewButton("Delete", func() {
-		// Ensure a file is selected in fileSelect
-		if fileSelect.Selected == "" || fileSelect.Selected == "[Create New]" {
-			dialog.ShowInformation("No Selection", "Please select a valid file to delete.", myWindow)
+		// Ensure a competition is selected in fileSelect
+		id, exists := lookupCompetitionID(fileSelect.Selected)
+		if fileSelect.Selected == "" || fileSelect.Selected == createNewOption || !exists {
+			dialog.ShowInformation("No Selection", "Please select a valid competition to delete.", myWindow)
 			return
 		}
 
-		// Find the index of the selected file
+		// Find the index of the selected competition
 		selectedIndex := -1
-		for i, file := range fileSelect.Options {
-			if file == fileSelect.Selected {
+		listMutex.Lock()
+		for i, option := range fileSelect.Options {
+			if option == fileSelect.Selected {
 				selectedIndex = i
 				break
 			}
 		}
-
-		if selectedIndex == -1 {
-			dialog.ShowInformation("File Not Found", "The selected file could not be found in the list.", myWindow)
-			return
-		}
+		listMutex.Unlock()
 
 		// Show confirmation dialog
 		dialog.ShowConfirm("Confirm Delete",
 			fmt.Sprintf("Are you sure you want to delete '%s'?", fileSelect.Selected),
 			func(confirm bool) {
 				if confirm {
-					// Construct the file path using filepath.Join
-					filePath := filepath.Join(dataDir, fileSelect.Selected)
-
-					// Attempt to delete the file
-					err := os.Remove(filePath)
-					if err != nil {
-						dialog.ShowError(fmt.Errorf("Failed to delete file: %w", err), myWindow)
+					// Attempt to delete the competition
+					if err := store.Delete(id); err != nil {
+						dialog.ShowError(fmt.Errorf("Failed to delete competition: %w", err), myWindow)
 						return
 					}
 
 					// Refresh the fileSelect options
-					files, _ := loadCompetitionFiles()
-					files = append(files, "[Create New]")
-					fileSelect.Options = files
-					fileSelect.Refresh()
+					refreshFileSelect("")
+					listMutex.Lock()
+					options := fileSelect.Options
+					listMutex.Unlock()
 
-					// Determine the next file to select
+					// Determine the next competition to select
 					nextIndex := selectedIndex
-					if nextIndex >= len(files)-1 {
-						// If deleted file was the last in the list, move to the previous file
-						nextIndex = len(files) - 2
+					if nextIndex >= len(options)-1 {
+						// If deleted competition was the last in the list, move to the previous one
+						nextIndex = len(options) - 2
 					}
 
-					if len(files) == 1 { // Only [Create New] remains
-						fileSelect.SetSelected("[Create New]")
+					if len(options) == 1 { // Only [Create New] remains
+						selectOption(createNewOption)
 						right.Hide()
 						left.Hide()
-					} else if nextIndex >= 0 && nextIndex < len(files) {
-						// Automatically select the next file if available
-						fileSelect.SetSelected(files[nextIndex])
-						loadCompetition(
-							files[nextIndex],
-							nameEntry,
-							templateSheetSelect,
-							&jurors,
-							&contestants,
-							fileMap,
-							&fileMapMutex,
-							juryTable,
-							contestantTable,
-						)
-						right.Show()
-						left.Show()
+					} else if nextIndex >= 0 && nextIndex < len(options) {
+						// Automatically select and load the next competition if available
+						selectOption(options[nextIndex])
 					}
 				}
 			},
@@ -273,6 +643,218 @@ func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
 		)
 	})
 
+	// Competition actions menu
+	var actionsButton *widget.Button
+	actionsButton = widget.NewButtonWithIcon("", theme.MoreHorizontalIcon(), func() {
+		requireSaved := func() bool {
+			if current.ID == "" {
+				dialog.ShowInformation("Not Saved", "Please save the competition first.", myWindow)
+				return false
+			}
+			return true
+		}
+
+		// Locks or unlocks the juror spreadsheets and records it in the open competition
+		lockScoring := func(lock bool) {
+			if !requireSaved() {
+				return
+			}
+			if current.Generation.SpreadsheetCount() == 0 {
+				dialog.ShowInformation("Scoring", "Generate the spreadsheets first.", myWindow)
+				return
+			}
+			competition := buildCurrentCompetition()
+			showScoringLock(myApp, myWindow, competition, lock, func() {
+				// Keeps the store watcher from reporting the recorded event as an outside change
+				listMutex.Lock()
+				open := openedID == competition.ID
+				listMutex.Unlock()
+				if open {
+					setKnownModified(competition.ID)
+				}
+			}, func(event ScoringLockEvent) {
+				if current.ID != competition.ID {
+					return
+				}
+				clean := !hasUnsavedChanges()
+				current.ScoringLog = append(current.ScoringLog, event)
+				if clean {
+					openedSnapshot = snapshot()
+				}
+				noteDraft()
+			})
+		}
+
+		menu := fyne.NewMenu("",
+			fyne.NewMenuItem("Rename...", func() {
+				if !requireSaved() {
+					return
+				}
+				showNameDialog("Rename Competition", "Rename", nameEntry.Text, myWindow, func(name string) {
+					// Only the stored name changes, other unsaved edits stay unsaved
+					name = strings.TrimSpace(name)
+					clean := !hasUnsavedChanges()
+					changedOutside := storedModified(current.ID).After(getKnownModified())
+					if err := store.Rename(current.ID, name); err != nil {
+						dialog.ShowError(err, myWindow)
+						return
+					}
+					history.Suspend(func() {
+						nameEntry.SetText(name)
+					})
+					lastName = name
+					if clean && !changedOutside {
+						openedSnapshot = snapshot()
+					}
+					if !changedOutside {
+						setKnownModified(current.ID)
+					}
+					noteDraft()
+					updateFileSelect()
+				})
+			}),
+			fyne.NewMenuItem("Duplicate", func() {
+				if !requireSaved() {
+					return
+				}
+				saveAsNew(buildCurrentCompetition(), fmt.Sprintf("%s (copy)", strings.TrimSpace(nameEntry.Text)))
+			}),
+			fyne.NewMenuItem("Save As...", func() {
+				showNameDialog("Save Competition As", "Save", nameEntry.Text, myWindow, func(name string) {
+					saveAsNew(buildCurrentCompetition(), name)
+				})
+			}),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("New from Preset...", func() {
+				showNewFromPreset(myWindow, func(comp Competition) {
+					confirmDiscardChanges(func() {
+						selectOption(createNewOption)
+						showUnsavedCompetition(comp)
+					})
+				})
+			}),
+			fyne.NewMenuItem("Save as Preset...", func() {
+				showSavePreset(myWindow, buildCurrentCompetition())
+			}),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("History...", func() {
+				if !requireSaved() {
+					return
+				}
+				history, ok := store.(HistoryStore)
+				if !ok {
+					dialog.ShowInformation("History", "The competition store does not keep earlier versions.", myWindow)
+					return
+				}
+				showHistoryWindow(myApp, history, buildCurrentCompetition(), func(restored Competition) {
+					if err := store.Save(restored); err != nil {
+						dialog.ShowError(err, myWindow)
+						return
+					}
+					refreshFileSelect(restored.ID)
+				})
+			}),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Results...", func() {
+				if !requireSaved() {
+					return
+				}
+				if current.Generation.SpreadsheetCount() == 0 {
+					dialog.ShowInformation("Results", "Generate the spreadsheets first. Results are read from the juror spreadsheets.", myWindow)
+					return
+				}
+				showResultsWindow(myApp, buildCurrentCompetition())
+			}),
+			fyne.NewMenuItem("Leaderboard...", func() {
+				if !requireSaved() {
+					return
+				}
+				if current.Generation.SpreadsheetCount() == 0 {
+					dialog.ShowInformation("Leaderboard", "Generate the spreadsheets first. The leaderboard is computed from the juror spreadsheets.", myWindow)
+					return
+				}
+				showLeaderboard(myApp, buildCurrentCompetition())
+			}),
+			fyne.NewMenuItem("Scoring Monitor...", func() {
+				if !requireSaved() {
+					return
+				}
+				if current.Generation.SpreadsheetCount() == 0 {
+					dialog.ShowInformation("Scoring Monitor", "Generate the spreadsheets first. The monitor reads the juror spreadsheets.", myWindow)
+					return
+				}
+				showScoringMonitor(myApp, buildCurrentCompetition())
+			}),
+			fyne.NewMenuItem("Lock Scoring...", func() {
+				lockScoring(true)
+			}),
+			fyne.NewMenuItem("Unlock Scoring...", func() {
+				lockScoring(false)
+			}),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Export Bundle...", func() {
+				if !requireSaved() {
+					return
+				}
+				exportCompetitionBundle(myApp, myWindow, buildCurrentCompetition(), templateSheetSelect.Selected)
+			}),
+			fyne.NewMenuItem("Import Bundle...", func() {
+				hasTemplate := func(id string) bool {
+					fileMapMutex.RLock()
+					defer fileMapMutex.RUnlock()
+					for _, templateID := range fileMap {
+						if templateID == id {
+							return true
+						}
+					}
+					return false
+				}
+				importCompetitionBundle(myWindow, hasTemplate, func(id string) {
+					refreshFileSelect("")
+					confirmDiscardChanges(func() {
+						refreshFileSelect(id)
+					})
+				})
+			}),
+		)
+		position := fyne.CurrentApp().Driver().AbsolutePositionForObject(actionsButton)
+		widget.ShowPopUpMenuAtPosition(menu, myWindow.Canvas(), position.Add(fyne.NewPos(0, actionsButton.Size().Height)))
+	})
+
+	// Event details button
+	eventButton := widget.NewButton("Event Details...", func() {
+		showEventDetails(myApp, history, &current.Event)
+	})
+
+	// Schedule button
+	scheduleButton := widget.NewButton("Schedule...", func() {
+		showScheduleEditor(myApp, history, &current.Schedule, &contestants)
+	})
+
+	// Weights button
+	weightsButton := widget.NewButton("Weights...", func() {
+		showWeightsEditor(myApp, history, current, &jurors)
+	})
+
+	// Scoring rules button
+	rankingButton := widget.NewButton("Scoring Rules...", func() {
+		showRankingRules(myApp, history, current, &jurors)
+	})
+
+	// Draw button
+	drawButton := widget.NewButton("Draw Order...", func() {
+		showDrawWindow(myApp, history, current, &contestants, contestantTable)
+	})
+
+	// Roster import button
+	importButton := widget.NewButton("Import...", func() {
+		showRosterImport(myApp, history, &jurors, &contestants, func() {
+			juryTable.Refresh()
+			contestantTable.Refresh()
+			updateWeightSum()
+		})
+	})
+
 	spaceAbove := canvas.NewRectangle(color.Transparent)
 	spaceAbove.SetMinSize(fyne.NewSize(0, 10))
 
@@ -284,6 +866,15 @@ func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
 		widget.NewLabelWithStyle("Template Sheet:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		templateSheetSelectorContainer,
 
+		container.NewHBox(
+			eventButton,
+			scheduleButton,
+			drawButton,
+			weightsButton,
+			rankingButton,
+			importButton,
+		),
+
 		spaceAbove,
 
 		container.NewHBox(
@@ -318,21 +909,81 @@ func fynelayout(myWindow fyne.Window, myApp fyne.App) *fyne.Container {
 		NewPaddedContainer(
 			container.NewVBox(
 				widget.NewLabelWithStyle("Select Competition:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				fileSelect,
+				container.NewBorder(nil, nil, nil, actionsButton, fileSelect),
 				left,
 			), 10),
 		NewPaddedContainer(right, 10),
 	)
 
-	return twoColumnLayout
+	// Keep the competition list up to date when competitions are changed outside this window.
+	// The open competition is not reloaded, so unsaved edits are kept.
+	var warnedModified time.Time
+	if _, err := store.Watch(func() {
+		updateFileSelect()
+
+		listMutex.Lock()
+		id, option, known := openedID, openedOption, knownModified
+		listMutex.Unlock()
+		if modified := storedModified(id); !modified.IsZero() && modified.After(known) && !modified.Equal(warnedModified) {
+			warnedModified = modified
+			logField.SetText(fmt.Sprintf("%s'%s' was changed outside this window. Saving will ask before overwriting those changes.\n", logField.Text, option))
+		}
+	}); err != nil {
+		log.Printf("Failed to watch competitions: %v", err)
+	}
+
+	actions := &layoutActions{
+		RefreshCompetitions: func() {
+			refreshFileSelect("")
+		},
+		History: history,
+		SaveDraft: func() {
+			noteDraft()
+			autosaveDraft()
+		},
+		RecoverDrafts: func() {
+			offerDraftRecovery(myWindow, func(draft Draft) {
+				// Open the stored competition, or a new one if it was never saved, and put the
+				// draft on top so it counts as unsaved changes
+				if draft.Competition.ID != "" && !storedModified(draft.Competition.ID).IsZero() {
+					refreshFileSelect(draft.Competition.ID)
+				} else {
+					selectOption(createNewOption)
+				}
+				draftKey = draft.Key
+				setOwnDraft(draft.Key, true)
+				showUnsavedCompetition(draft.Competition)
+			})
+		},
+	}
+
+	// Autosave unsaved changes, so they can be recovered after a crash
+	go func() {
+		for range time.Tick(autosaveInterval) {
+			autosaveDraft()
+		}
+	}()
+
+	return twoColumnLayout, actions
 }
 
 var jurorsMutex sync.RWMutex
 
-func createJuryTable(jurors *[]*Juror) (*fyne.Container, *widget.Table) {
+func createJuryTable(jurors *[]*Juror, history *UndoHistory) (*fyne.Container, *widget.Table, func()) {
+
+	// Live sum of the juror weights
+	weightSumText := canvas.NewText("", color.Black)
+	weightSumText.TextSize = 12
+	updateWeightSum := func() {
+		jurorsMutex.RLock()
+		total, count := sumJurorWeights(*jurors), len(*jurors)
+		jurorsMutex.RUnlock()
+		updateWeightSumText(weightSumText, total, count > 0)
+	}
 
 	// Create the jury table
-	juryTable := widget.NewTable(
+	var juryTable *widget.Table
+	juryTable = widget.NewTable(
 		func() (int, int) {
 			jurorsMutex.RLock()
 			defer jurorsMutex.RUnlock()
@@ -340,31 +991,50 @@ func createJuryTable(jurors *[]*Juror) (*fyne.Container, *widget.Table) {
 		},
 		func() fyne.CanvasObject {
 			// Create a single Entry for each cell
-			return widget.NewEntry()
+			return newHistoryEntry(history)
 		},
 		func(id widget.TableCellID, obj fyne.CanvasObject) {
-			entry := obj.(*widget.Entry)
+			entry := obj.(*historyEntry)
 
 			jurorsMutex.RLock()
 			defer jurorsMutex.RUnlock()
 
+			juror := (*jurors)[id.Row]
 			if id.Col == 0 { // Name column
 				entry.OnChanged = nil // Must release the old OnChanged function before setting the text below, otherwise it would lock up!
-				entry.SetText((*jurors)[id.Row].Name)
+				entry.SetText(juror.Name)
 				entry.OnChanged = func(newText string) {
 					jurorsMutex.Lock()
-					defer jurorsMutex.Unlock()
-					(*jurors)[id.Row].Name = strings.TrimSpace(newText)
+					oldName := juror.Name
+					juror.Name = strings.TrimSpace(newText)
+					newName := juror.Name
+					jurorsMutex.Unlock()
+					history.Record(&fieldEdit{key: fmt.Sprintf("juror-name-%p", juror), name: "Juror Name", oldValue: oldName, newValue: newName, typing: true, apply: func(value string) {
+						jurorsMutex.Lock()
+						juror.Name = value
+						jurorsMutex.Unlock()
+						juryTable.Refresh()
+					}})
 				}
 			} else if id.Col == 1 { // Weight column
 				entry.OnChanged = nil // Must release the old OnChanged function before setting the text below, otherwise it would lock up!
-				entry.SetText(fmt.Sprintf("%d", (*jurors)[id.Row].Weight))
+				entry.SetText(fmt.Sprintf("%d", juror.Weight))
 				entry.OnChanged = func(newText string) {
-					jurorsMutex.Lock()
-					defer jurorsMutex.Unlock()
 					weight, err := strconv.Atoi(strings.TrimSpace(newText))
 					if err == nil {
-						(*jurors)[id.Row].Weight = weight
+						jurorsMutex.Lock()
+						oldWeight := juror.Weight
+						juror.Weight = weight
+						jurorsMutex.Unlock()
+						updateWeightSum()
+						history.Record(&fieldEdit{key: fmt.Sprintf("juror-weight-%p", juror), name: "Juror Weight", oldValue: strconv.Itoa(oldWeight), newValue: strconv.Itoa(weight), typing: true, apply: func(value string) {
+							weight, _ := strconv.Atoi(value)
+							jurorsMutex.Lock()
+							juror.Weight = weight
+							jurorsMutex.Unlock()
+							juryTable.Refresh()
+							updateWeightSum()
+						}})
 					}
 				}
 			}
@@ -388,59 +1058,151 @@ func createJuryTable(jurors *[]*Juror) (*fyne.Container, *widget.Table) {
 		layout.NewSpacer(),
 		widget.NewButton("Add", func() {
 			// Append a new juror with default values
-			*jurors = append(*jurors, &Juror{Name: "", Weight: 0})
-			juryTable.Refresh()
+			juror := &Juror{Name: "", Weight: 0}
+			history.Execute(newCommand("Add Juror", func() {
+				jurorsMutex.Lock()
+				*jurors = append(*jurors, juror)
+				jurorsMutex.Unlock()
+				juryTable.Refresh()
+				updateWeightSum()
+			}, func() {
+				jurorsMutex.Lock()
+				*jurors = removeItem(*jurors, juror)
+				jurorsMutex.Unlock()
+				juryTable.Refresh()
+				updateWeightSum()
+			}))
 		}),
 		widget.NewButton("Remove", func() {
 			// Remove the last juror if there are any
-			if len(*jurors) > 0 {
-				*jurors = (*jurors)[:len(*jurors)-1]
-				juryTable.Refresh()
+			jurorsMutex.RLock()
+			index := len(*jurors) - 1
+			if index < 0 {
+				jurorsMutex.RUnlock()
+				return
 			}
+			juror := (*jurors)[index]
+			jurorsMutex.RUnlock()
+			history.Execute(newCommand("Remove Juror", func() {
+				jurorsMutex.Lock()
+				*jurors = removeItem(*jurors, juror)
+				jurorsMutex.Unlock()
+				juryTable.Refresh()
+				updateWeightSum()
+			}, func() {
+				jurorsMutex.Lock()
+				*jurors = insertItem(*jurors, index, juror)
+				jurorsMutex.Unlock()
+				juryTable.Refresh()
+				updateWeightSum()
+			}))
 		}),
 	)
 
-	// Return the complete layout and the table widget
+	// Footer with the weight sum and a button to rescale the weights to 100%
+	footer := container.NewHBox(
+		weightSumText,
+		layout.NewSpacer(),
+		widget.NewButton("Normalize", func() {
+			history.Execute(normalizeJurorWeights(jurors, func() {
+				juryTable.Refresh()
+				updateWeightSum()
+			}))
+		}),
+	)
+	updateWeightSum()
+
+	// Return the complete layout, the table widget and the function updating the weight sum
 	return container.NewVBox(
 		header,
 		tableWithBounds,
-	), juryTable
+		footer,
+	), juryTable, updateWeightSum
+}
+
+// normalizeJurorWeights returns the command that rescales the juror weights to add up to 100%
+func normalizeJurorWeights(jurors *[]*Juror, refresh func()) Command {
+	jurorsMutex.RLock()
+	affected := append([]*Juror(nil), *jurors...)
+	weights := make([]int, len(affected))
+	for i, juror := range affected {
+		weights[i] = juror.Weight
+	}
+	jurorsMutex.RUnlock()
+	normalized := normalizeWeights(weights)
+
+	setWeights := func(values []int) {
+		jurorsMutex.Lock()
+		for i, juror := range affected {
+			juror.Weight = values[i]
+		}
+		jurorsMutex.Unlock()
+		refresh()
+	}
+	return newCommand("Normalize Weights", func() {
+		setWeights(normalized)
+	}, func() {
+		setWeights(weights)
+	})
 }
 
 var contestantsMutex sync.RWMutex
 
-func createContestantsTable(contestants *[]*Contestant) (*fyne.Container, *widget.Table) {
+func createContestantsTable(contestants *[]*Contestant, history *UndoHistory) (*fyne.Container, *widget.Table) {
+
+	// setField changes a text field of a contestant and records the edit
+	var contestantsTable *widget.Table
+	setField := func(contestant *Contestant, field *string, key, name, newText string) {
+		contestantsMutex.Lock()
+		oldValue := *field
+		*field = strings.TrimSpace(newText)
+		newValue := *field
+		contestantsMutex.Unlock()
+		history.Record(&fieldEdit{key: fmt.Sprintf("%s-%p", key, contestant), name: name, oldValue: oldValue, newValue: newValue, typing: true, apply: func(value string) {
+			contestantsMutex.Lock()
+			*field = value
+			contestantsMutex.Unlock()
+			contestantsTable.Refresh()
+		}})
+	}
 
 	// Create the contestants table
-	contestantsTable := widget.NewTable(
+	contestantsTable = widget.NewTable(
 		func() (int, int) {
 			contestantsMutex.RLock()
 			defer contestantsMutex.RUnlock()
-			return len(*contestants), 1 // Rows: contestants count, Columns: 1 (Name)
+			return len(*contestants), 2 // Rows: contestants count, Columns: 2 (Name, Team)
 		},
 		func() fyne.CanvasObject {
 			// Create a single Entry for each cell
-			return widget.NewEntry()
+			return newHistoryEntry(history)
 		},
 		func(id widget.TableCellID, obj fyne.CanvasObject) {
-			entry := obj.(*widget.Entry)
+			entry := obj.(*historyEntry)
 
 			contestantsMutex.RLock()
 			defer contestantsMutex.RUnlock()
 
-			// Populate the name field
-			entry.OnChanged = nil
-			entry.SetText((*contestants)[id.Row].Name)
-			entry.OnChanged = func(newText string) {
-				contestantsMutex.Lock()
-				defer contestantsMutex.Unlock()
-				(*contestants)[id.Row].Name = strings.TrimSpace(newText)
+			contestant := (*contestants)[id.Row]
+			if id.Col == 0 { // Name column
+				entry.OnChanged = nil
+				entry.SetText(contestant.Name)
+				entry.OnChanged = func(newText string) {
+					setField(contestant, &contestant.Name, "contestant-name", "Contestant Name", newText)
+				}
+			} else if id.Col == 1 { // Team column
+				entry.OnChanged = nil
+				entry.SetText(contestant.Team)
+				entry.OnChanged = func(newText string) {
+					setField(contestant, &contestant.Team, "contestant-team", "Contestant Team", newText)
+				}
 			}
 		},
 	)
 
-	// Set column width for the name column
-	contestantsTable.SetColumnWidth(0, 380) // Name column width
+	// Set column widths for proper sizing
+	contestantsTable.SetColumnWidth(0, 280) // Name column width
+	contestantsTable.SetColumnWidth(1, 100) // Team column width
 
 	// Add a bounding rectangle to enforce table size
 	boundingBox := canvas.NewRectangle(nil)
@@ -455,15 +1217,40 @@ func createContestantsTable(contestants *[]*Contestant) (*fyne.Container, *widge
 		layout.NewSpacer(),
 		widget.NewButton("Add", func() {
 			// Append a new contestant with default values
-			*contestants = append(*contestants, &Contestant{Name: ""})
-			contestantsTable.Refresh()
+			contestant := &Contestant{Name: ""}
+			history.Execute(newCommand("Add Contestant", func() {
+				contestantsMutex.Lock()
+				*contestants = append(*contestants, contestant)
+				contestantsMutex.Unlock()
+				contestantsTable.Refresh()
+			}, func() {
+				contestantsMutex.Lock()
+				*contestants = removeItem(*contestants, contestant)
+				contestantsMutex.Unlock()
+				contestantsTable.Refresh()
+			}))
 		}),
 		widget.NewButton("Remove", func() {
 			// Remove the last contestant if there are any
-			if len(*contestants) > 0 {
-				*contestants = (*contestants)[:len(*contestants)-1]
-				contestantsTable.Refresh()
+			contestantsMutex.RLock()
+			index := len(*contestants) - 1
+			if index < 0 {
+				contestantsMutex.RUnlock()
+				return
 			}
+			contestant := (*contestants)[index]
+			contestantsMutex.RUnlock()
+			history.Execute(newCommand("Remove Contestant", func() {
+				contestantsMutex.Lock()
+				*contestants = removeItem(*contestants, contestant)
+				contestantsMutex.Unlock()
+				contestantsTable.Refresh()
+			}, func() {
+				contestantsMutex.Lock()
+				*contestants = insertItem(*contestants, index, contestant)
+				contestantsMutex.Unlock()
+				contestantsTable.Refresh()
+			}))
 		}),
 	)
 
@@ -547,16 +1334,6 @@ func createTemplateSheetSelector(
 		}
 	}()
 
-	// Handle selection change
-	templateSheetSelect.OnChanged = func(selected string) {
-		fileMapMutex.RLock()
-		defer fileMapMutex.RUnlock()
-
-		// if id, exists := (*fileMap)[selected]; exists {
-		// 	fmt.Printf("Selected File Name: %s, ID: %s\n", selected, id)
-		// }
-	}
-
 	// Return the layout
 	return container.NewVBox(
 		templateSheetSelect,
@@ -586,6 +1363,26 @@ func validateCompetition(comp Competition) error {
 		}
 	}
 
+	// Check that juror and criterion weights add up
+	if err := validateWeights(comp); err != nil {
+		return err
+	}
+
+	// Check the points range
+	if err := validatePointsRange(comp); err != nil {
+		return err
+	}
+
+	// Check that the aggregation mode suits the jury
+	if err := validateAggregation(comp); err != nil {
+		return err
+	}
+
+	// Check that the tie-break rules refer to existing criteria and jurors
+	if err := validateTieBreaks(comp); err != nil {
+		return err
+	}
+
 	// Check for at least one contestant
 	if len(comp.Contestants) == 0 {
 		return fmt.Errorf("There must be at least one contestant.")
@@ -598,6 +1395,11 @@ func validateCompetition(comp Competition) error {
 		}
 	}
 
+	// Check the event details
+	if err := comp.Event.Validate(); err != nil {
+		return err
+	}
+
 	// Check if a template sheet is defined
 	if strings.TrimSpace(comp.SourceSheetID) == "" {
 		return fmt.Errorf("A template sheet must be defined.")
@@ -607,29 +1409,18 @@ func validateCompetition(comp Competition) error {
 	return nil
 }
 
-func buildCompetition(name, sourceSheetID string, jurors []*Juror, contestants []*Contestant) Competition {
-	return Competition{
-		Name:          name,
-		SourceSheetID: sourceSheetID,
-		Jury:          jurors,
-		Contestants:   contestants,
-	}
-}
-
-func saveCompetition(comp Competition) error {
-	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
-		os.Mkdir(dataDir, 0755)
-	}
-	data, err := json.MarshalIndent(comp, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to serialize competition: %w", err)
-	}
-	filePath := fmt.Sprintf("%s/%s.json", dataDir, comp.Name)
-	return os.WriteFile(filePath, data, 0644)
+func buildCompetition(base *Competition, name, sourceSheetID string, jurors []*Juror, contestants []*Contestant) Competition {
+	comp := *base
+	comp.Name = name
+	comp.SourceSheetID = sourceSheetID
+	comp.Jury = jurors
+	comp.Contestants = contestants
+	return comp
 }
 
 func loadCompetition(
-	filename string,
+	id string,
+	current *Competition,
 	nameEntry *widget.Entry,
 	templateSheetSelector *widget.Select,
 	jurors *[]*Juror,
@@ -638,26 +1429,38 @@ func loadCompetition(
 	fileMapMutex *sync.RWMutex,
 	jurorsTable *widget.Table,
 	contestantsTable *widget.Table,
-) {
+) error {
 	var comp Competition
 
-	if filename == "[Create New]" {
+	if id == createNewOption {
 		comp = Competition{}
 	} else {
-		filePath := fmt.Sprintf("%s/%s", dataDir, filename)
-		data, err := os.ReadFile(filePath)
+		var err error
+		comp, err = store.Load(id)
 		if err != nil {
-			log.Printf("Failed to load competition: %v", err)
-			return
-		}
-
-		if err := json.Unmarshal(data, &comp); err != nil {
-			log.Printf("Failed to parse competition: %v", err)
-			return
+			return err
 		}
 	}
 
+	populateCompetition(comp, current, nameEntry, templateSheetSelector, jurors, contestants, fileMap, fileMapMutex, jurorsTable, contestantsTable)
+	return nil
+}
+
+// populateCompetition shows a competition in the widgets
+func populateCompetition(
+	comp Competition,
+	current *Competition,
+	nameEntry *widget.Entry,
+	templateSheetSelector *widget.Select,
+	jurors *[]*Juror,
+	contestants *[]*Contestant,
+	fileMap map[string]string,
+	fileMapMutex *sync.RWMutex,
+	jurorsTable *widget.Table,
+	contestantsTable *widget.Table,
+) {
 	// Populate competition details
+	*current = comp
 	nameEntry.SetText(comp.Name)
 
 	// Look up SourceSheetID in fileMap to set the display name
@@ -707,19 +1510,23 @@ func formatContestants(contestants []*Contestant) string {
 	return result
 }
 
-func loadCompetitionFiles() ([]string, error) {
-	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
-		return nil, nil
-	}
-	files, err := os.ReadDir(dataDir)
-	if err != nil {
-		return nil, err
-	}
-	var names []string
-	for _, file := range files {
-		if !file.IsDir() {
-			names = append(names, file.Name())
+// showNameDialog asks for a competition name and calls onConfirm with the trimmed, non-empty name
+func showNameDialog(title, confirm, initial string, parent fyne.Window, onConfirm func(name string)) {
+	entry := widget.NewEntry()
+	entry.SetText(initial)
+	entry.Validator = func(text string) error {
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("Competition name cannot be empty.")
 		}
+		return nil
 	}
-	return names, nil
+	dialog.ShowForm(title, confirm, "Cancel",
+		[]*widget.FormItem{widget.NewFormItem("Name", entry)},
+		func(ok bool) {
+			if ok {
+				onConfirm(strings.TrimSpace(entry.Text))
+			}
+		},
+		parent,
+	)
 }
@@ -4,6 +4,7 @@ go 1.23.0
 
 require (
 	fyne.io/fyne/v2 v2.5.2
+	github.com/fsnotify/fsnotify v1.8.0
 	github.com/s00500/env_logger v0.1.29
 	google.golang.org/api v0.211.0
 )
@@ -18,7 +19,6 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/felixge/httpsnoop v1.0.4 // indirect
 	github.com/fredbi/uri v1.1.0 // indirect
-	github.com/fsnotify/fsnotify v1.8.0 // indirect
 	github.com/fyne-io/gl-js v0.0.0-20230506162202-1fdaa286a934 // indirect
 	github.com/fyne-io/glfw-js v0.0.0-20241126112943-313d8a0fe1d0 // indirect
 	github.com/fyne-io/image v0.0.0-20240417123036-dc0ee9e7c964 // indirect
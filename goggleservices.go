@@ -5,87 +5,140 @@ import (
 
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
 
-func generateGoogleSheets(ctx context.Context, credentials string, parentFolderID string, competition Competition, logStatus func(message string)) error {
+// generateGoogleSheets creates the overview and juror spreadsheets of a competition and
+// returns the manifest of everything it created
+func generateGoogleSheets(ctx context.Context, credentials string, parentFolderID string, competition Competition, logStatus func(message string)) (*GenerationManifest, error) {
+	// Contestant tabs follow the running order of the schedule
+	competition.Contestants = runningOrder(competition.Contestants)
+	manifest := &GenerationManifest{GeneratedAt: time.Now().UTC()}
+
 	// Initialize services
 	services, err := initializeGoogleServices(ctx, credentials)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create a folder for the competition
 
 	if err := checkContext(ctx); err != nil {
-		return err
+		return nil, err
 	}
 	newFolderID, err := createFolder(ctx, services.Drive, parentFolderID, competition.Name, logStatus)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	manifest.FolderID = newFolderID
 
 	// Create an overview sheet
 
 	if err := checkContext(ctx); err != nil {
-		return err
+		return nil, err
+	}
+	manifest.Template, err = fetchTemplateReference(ctx, services.Drive, competition.SourceSheetID)
+	if err != nil {
+		return nil, err
 	}
 	adminSheetID, err := copyTemplateSheet(ctx, services, newFolderID, competition, logStatus)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	manifest.OverviewID = adminSheetID
+
+	// Insert the event header at the top of every sheet
+
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	headerRows, err := insertEventHeader(ctx, services.Sheets, adminSheetID, competition, logStatus)
+	if err != nil {
+		return nil, err
+	}
+	manifest.HeaderRows = headerRows
 
 	// Find and process the "Board" sheet
 
 	if err := checkContext(ctx); err != nil {
-		return err
+		return nil, err
 	}
 	boardSheetID, pointsAndTotal, err := findBoardSheet(ctx, services.Sheets, adminSheetID, logStatus)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	manifest.PointsRows = pointsAndTotal
 
 	// Duplicate sheets and insert contestant names
 
 	if err := checkContext(ctx); err != nil {
-		return err
+		return nil, err
 	}
 	sheetNames, err := duplicateAndNameSheets(ctx, services.Sheets, adminSheetID, boardSheetID, competition, logStatus)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for i, contestant := range competition.Contestants {
+		manifest.Tabs = append(manifest.Tabs, ContestantTab{Contestant: contestant.Name, Sheet: sheetNames[len(sheetNames)-i-1]})
 	}
 
 	// Insert contestant names
 
 	if err := checkContext(ctx); err != nil {
-		return err
+		return nil, err
 	}
-	if err := insertContestantNames(ctx, services.Sheets, adminSheetID, competition, sheetNames, logStatus); err != nil {
-		return err
+	if err := insertContestantNames(ctx, services.Sheets, adminSheetID, competition, sheetNames, headerRows, logStatus); err != nil {
+		return nil, err
 	}
 
 	// Delete the original "Board" sheet
 
 	if err := checkContext(ctx); err != nil {
-		return err
+		return nil, err
 	}
 	if err := deleteBoardSheet(ctx, services.Sheets, adminSheetID, boardSheetID, logStatus); err != nil {
-		return err
+		return nil, err
+	}
+
+	// Add the schedule to the Overview
+
+	if hasSchedule(competition.Contestants) {
+		if err := checkContext(ctx); err != nil {
+			return nil, err
+		}
+		if err := addScheduleSheet(ctx, services.Sheets, adminSheetID, competition, sheetNames, logStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	// Add the weights to the Overview
+
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	if err := addWeightsSheet(ctx, services.Sheets, adminSheetID, competition, logStatus); err != nil {
+		return nil, err
 	}
 
 	// Create spreadsheets for jurors
 
 	if err := checkContext(ctx); err != nil {
-		return err
+		return nil, err
 	}
-	if err := createJurorSheets(ctx, services, adminSheetID, newFolderID, competition, sheetNames, pointsAndTotal, logStatus); err != nil {
-		return err
+	jurorSheetIDs, err := createJurorSheets(ctx, services, adminSheetID, newFolderID, competition, sheetNames, pointsAndTotal, logStatus)
+	if err != nil {
+		return nil, err
+	}
+	for i, juror := range competition.Jury {
+		manifest.JurorSheets = append(manifest.JurorSheets, JurorSheet{Juror: juror.Name, SpreadsheetID: jurorSheetIDs[i]})
 	}
 
-	return nil
+	return manifest, nil
 }
 
 func initializeGoogleServices(ctx context.Context, credentials string) (*GoogleServices, error) {
@@ -115,6 +168,28 @@ func createFolder(ctx context.Context, driveService *drive.Service, parentFolder
 	return createdFolder.Id, nil
 }
 
+// fetchTemplateReference looks up the name and current revision of the template spreadsheet
+func fetchTemplateReference(ctx context.Context, driveService *drive.Service, templateID string) (TemplateReference, error) {
+	file, err := driveService.Files.Get(templateID).Fields("id, name, version, modifiedTime").Context(ctx).Do()
+	if err != nil {
+		return TemplateReference{}, fmt.Errorf("unable to read template details: %v", err)
+	}
+	reference := TemplateReference{ID: file.Id, Name: file.Name, Revision: fmt.Sprintf("%d", file.Version)}
+	if modified, err := time.Parse(time.RFC3339, file.ModifiedTime); err == nil {
+		reference.Modified = modified
+	}
+	return reference, nil
+}
+
+// lookupTemplateReference connects to Drive and looks up the template spreadsheet
+func lookupTemplateReference(ctx context.Context, credentials, templateID string) (TemplateReference, error) {
+	driveService, err := drive.NewService(ctx, option.WithCredentialsJSON([]byte(credentials)))
+	if err != nil {
+		return TemplateReference{}, fmt.Errorf("unable to create Drive client: %v", err)
+	}
+	return fetchTemplateReference(ctx, driveService, templateID)
+}
+
 func copyTemplateSheet(ctx context.Context, services *GoogleServices, newFolderID string, competition Competition, logStatus func(message string)) (string, error) {
 	logStatus(fmt.Sprintf("Copying Template Spreadsheet ID %s for Overview...\n", competition.SourceSheetID))
 	newFile := &drive.File{
@@ -155,6 +230,56 @@ func findBoardSheet(ctx context.Context, sheetsService *sheets.Service, adminShe
 	return boardSheetID, pointsAndTotal, nil
 }
 
+// insertEventHeader inserts rows with the competition name and event details at the top of
+// every sheet in the Overview. It returns the number of rows inserted, which is zero when no
+// event details are set.
+func insertEventHeader(ctx context.Context, sheetsService *sheets.Service, adminSheetID string, competition Competition, logStatus func(message string)) (int, error) {
+	if competition.Event.IsEmpty() {
+		return 0, nil
+	}
+	logStatus("Inserting event header into each sheet...\n")
+
+	resp, err := sheetsService.Spreadsheets.Get(adminSheetID).Fields("sheets(properties(sheetId,title))").Do()
+	if err != nil {
+		return 0, fmt.Errorf("unable to get spreadsheet details: %v", err)
+	}
+
+	batchRequest := &sheets.BatchUpdateSpreadsheetRequest{Requests: []*sheets.Request{}}
+	for _, sheet := range resp.Sheets {
+		sheetID := sheet.Properties.SheetId
+		batchRequest.Requests = append(batchRequest.Requests, &sheets.Request{
+			InsertDimension: &sheets.InsertDimensionRequest{
+				Range: &sheets.DimensionRange{
+					SheetId:    sheetID,
+					Dimension:  "ROWS",
+					StartIndex: 0,
+					EndIndex:   headerRowCount,
+				},
+				InheritFromBefore: false,
+			},
+		})
+
+		// Logo in column A, competition name and details in column B
+		if competition.Event.LogoURL != "" {
+			logoFormula := fmt.Sprintf(`=IMAGE("%s")`, strings.ReplaceAll(competition.Event.LogoURL, `"`, `""`))
+			batchRequest.Requests = append(batchRequest.Requests,
+				createUpdateRequest(sheetID, 0, 0, logoFormula, "userEnteredValue"))
+		}
+		batchRequest.Requests = append(batchRequest.Requests,
+			createBoldUpdateRequest(sheetID, 0, 1, competition.Name))
+		for i, line := range competition.Event.headerLines() {
+			batchRequest.Requests = append(batchRequest.Requests,
+				createUpdateRequest(sheetID, int64(i+1), 1, line, "userEnteredValue"))
+		}
+	}
+
+	if _, err := sheetsService.Spreadsheets.BatchUpdate(adminSheetID, batchRequest).Do(); err != nil {
+		return 0, fmt.Errorf("unable to insert event header: %v", err)
+	}
+	logStatus(fmt.Sprintf("Event header inserted into %d sheet(s).\n", len(resp.Sheets)))
+	return headerRowCount, nil
+}
+
 func duplicateAndNameSheets(ctx context.Context, sheetsService *sheets.Service, adminSheetID string, boardSheetID int64, competition Competition, logStatus func(message string)) ([]string, error) {
 	logStatus(fmt.Sprintf("Duplicating sheet 'Board' %d times...\n", len(competition.Contestants)))
 	batchRequest := &sheets.BatchUpdateSpreadsheetRequest{
@@ -181,7 +306,7 @@ func duplicateAndNameSheets(ctx context.Context, sheetsService *sheets.Service,
 	return sheetNames, nil
 }
 
-func insertContestantNames(ctx context.Context, sheetsService *sheets.Service, adminSheetID string, competition Competition, sheetNames []string, logStatus func(message string)) error {
+func insertContestantNames(ctx context.Context, sheetsService *sheets.Service, adminSheetID string, competition Competition, sheetNames []string, headerRows int, logStatus func(message string)) error {
 	logStatus("Inserting contestant names into each duplicated sheet...\n")
 	batchRequest := &sheets.BatchUpdateSpreadsheetRequest{
 		Requests: []*sheets.Request{},
@@ -207,8 +332,8 @@ func insertContestantNames(ctx context.Context, sheetsService *sheets.Service, a
 		valueUpdateRequest := &sheets.UpdateCellsRequest{
 			Start: &sheets.GridCoordinate{
 				SheetId:     sheetID,
-				RowIndex:    1, // Row 2  (0-based indexing)
-				ColumnIndex: 1, // Column B (0-based indexing)
+				RowIndex:    int64(1 + headerRows), // Row 2 below the event header (0-based indexing)
+				ColumnIndex: 1,                     // Column B (0-based indexing)
 			},
 			Rows: []*sheets.RowData{
 				{
@@ -256,13 +381,177 @@ func deleteBoardSheet(ctx context.Context, sheetsService *sheets.Service, adminS
 	return nil
 }
 
-func createJurorSheets(ctx context.Context, services *GoogleServices, adminSheetID, newFolderID string, competition Competition, sheetNames []string, pointsAndTotal []RowColumnInfo, logStatus func(message string)) error {
+func addScheduleSheet(ctx context.Context, sheetsService *sheets.Service, adminSheetID string, competition Competition, sheetNames []string, logStatus func(message string)) error {
+	logStatus("Adding 'Schedule' sheet to the Overview...\n")
+	addSheetRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{
+				AddSheet: &sheets.AddSheetRequest{
+					Properties: &sheets.SheetProperties{
+						Title: "Schedule",
+					},
+				},
+			},
+		},
+	}
+	if _, err := sheetsService.Spreadsheets.BatchUpdate(adminSheetID, addSheetRequest).Do(); err != nil {
+		return fmt.Errorf("unable to add schedule sheet: %v", err)
+	}
+
+	// One row per contestant in running order
+	values := [][]interface{}{
+		{"#", "Sheet", "Contestant", "Sauna", "Start", "End"},
+	}
+	for i, contestant := range competition.Contestants {
+		row := []interface{}{i + 1, sheetNames[len(competition.Contestants)-i-1], contestant.Name, "", "", ""}
+		if contestant.Slot != nil && !contestant.Slot.Start.IsZero() {
+			row[3] = contestant.Slot.Sauna
+			row[4] = contestant.Slot.Start.Format(scheduleTimeLayout)
+			row[5] = competition.Schedule.SlotEnd(contestant.Slot).Format(scheduleTimeLayout)
+		}
+		values = append(values, row)
+	}
+	values = append(values,
+		[]interface{}{},
+		[]interface{}{"Performance (minutes):", int(competition.Schedule.Performance() / time.Minute)},
+		[]interface{}{"Break (minutes):", int(competition.Schedule.Break() / time.Minute)},
+	)
+
+	_, err := sheetsService.Spreadsheets.Values.Update(adminSheetID, "Schedule!A1", &sheets.ValueRange{Values: values}).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return fmt.Errorf("unable to write schedule: %v", err)
+	}
+	logStatus("Sheet 'Schedule' added to the Overview.\n")
+	return nil
+}
+
+// addWeightsSheet writes the juror and criterion weights to a 'Weights' sheet and exposes them
+// as the named ranges JurorWeights, CriterionWeights and WeightMatrix for the template formulas.
+func addWeightsSheet(ctx context.Context, sheetsService *sheets.Service, adminSheetID string, competition Competition, logStatus func(message string)) error {
+	logStatus("Adding 'Weights' sheet to the Overview...\n")
+	addSheetRequest := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{
+				AddSheet: &sheets.AddSheetRequest{
+					Properties: &sheets.SheetProperties{
+						Title: "Weights",
+					},
+				},
+			},
+		},
+	}
+	resp, err := sheetsService.Spreadsheets.BatchUpdate(adminSheetID, addSheetRequest).Do()
+	if err != nil {
+		return fmt.Errorf("unable to add weights sheet: %v", err)
+	}
+	weightsSheetID := resp.Replies[0].AddSheet.Properties.SheetId
+
+	// Juror shares of the total (see effectiveJurorShares), followed by the effective weight of
+	// each juror per criterion
+	jurorCount := len(competition.Jury)
+	criteriaCount := len(competition.Criteria)
+	matrix := effectiveJurorWeights(competition.Jury, competition.Criteria)
+	shares := effectiveJurorShares(competition.Jury, competition.Criteria)
+
+	header := []interface{}{"Juror", "Weight"}
+	for _, criterion := range competition.Criteria {
+		header = append(header, criterion.Name)
+	}
+	values := [][]interface{}{header}
+	for j, juror := range competition.Jury {
+		row := []interface{}{juror.Name, shares[j]}
+		for c := range competition.Criteria {
+			row = append(row, matrix[j][c])
+		}
+		values = append(values, row)
+	}
+
+	// Criterion weights below the jurors (see criterionWeightCell)
+	criteriaStartRow := jurorCount + 3 // 0-based row of the first criterion
+	values = append(values, []interface{}{}, []interface{}{"Criterion", "Weight"})
+	for _, criterion := range competition.Criteria {
+		values = append(values, []interface{}{criterion.Name, float64(criterion.Weight) / 100})
+	}
+
+	// Aggregation mode below the criteria
+	values = append(values, []interface{}{}, []interface{}{"Aggregation", aggregationName(aggregationMode(competition))})
+	if aggregationMode(competition) == aggregationTrimmedMean {
+		values = append(values, []interface{}{"Dropped at each end", trimCount(competition)})
+	}
+
+	_, err = sheetsService.Spreadsheets.Values.Update(adminSheetID, "Weights!A1", &sheets.ValueRange{Values: values}).ValueInputOption("RAW").Do()
+	if err != nil {
+		return fmt.Errorf("unable to write weights: %v", err)
+	}
+
+	// Named ranges for the template formulas
+	namedRanges := map[string]*sheets.GridRange{
+		"JurorWeights": {SheetId: weightsSheetID, StartRowIndex: 1, EndRowIndex: int64(1 + jurorCount), StartColumnIndex: 1, EndColumnIndex: 2},
+	}
+	if criteriaCount > 0 {
+		namedRanges["WeightMatrix"] = &sheets.GridRange{SheetId: weightsSheetID, StartRowIndex: 1, EndRowIndex: int64(1 + jurorCount), StartColumnIndex: 2, EndColumnIndex: int64(2 + criteriaCount)}
+		namedRanges["CriterionWeights"] = &sheets.GridRange{SheetId: weightsSheetID, StartRowIndex: int64(criteriaStartRow), EndRowIndex: int64(criteriaStartRow + criteriaCount), StartColumnIndex: 1, EndColumnIndex: 2}
+	}
+	if err := setNamedRanges(sheetsService, adminSheetID, namedRanges); err != nil {
+		return err
+	}
+
+	logStatus("Sheet 'Weights' added to the Overview.\n")
+	return nil
+}
+
+// weightMatrixCell returns the cell of the 'Weights' sheet with the effective weight of juror j
+// in criterion c
+func weightMatrixCell(j, c int) string {
+	return fmt.Sprintf("Weights!$%s$%d", columnIndexToLetter(c+3), j+2)
+}
+
+// criterionWeightCell returns the cell of the 'Weights' sheet with the weight of criterion c
+func criterionWeightCell(jurorCount, c int) string {
+	return fmt.Sprintf("Weights!$B$%d", jurorCount+c+4)
+}
+
+// setNamedRanges creates the named ranges, or points them at the new range if the template already defines them
+func setNamedRanges(sheetsService *sheets.Service, spreadsheetID string, namedRanges map[string]*sheets.GridRange) error {
+	existing, err := sheetsService.Spreadsheets.Get(spreadsheetID).Fields("namedRanges(namedRangeId,name)").Do()
+	if err != nil {
+		return fmt.Errorf("unable to fetch named ranges: %v", err)
+	}
+	existingIDs := make(map[string]string)
+	for _, namedRange := range existing.NamedRanges {
+		existingIDs[namedRange.Name] = namedRange.NamedRangeId
+	}
+
+	batchRequest := &sheets.BatchUpdateSpreadsheetRequest{Requests: []*sheets.Request{}}
+	for name, gridRange := range namedRanges {
+		if id, exists := existingIDs[name]; exists {
+			batchRequest.Requests = append(batchRequest.Requests, &sheets.Request{
+				UpdateNamedRange: &sheets.UpdateNamedRangeRequest{
+					NamedRange: &sheets.NamedRange{NamedRangeId: id, Name: name, Range: gridRange},
+					Fields:     "range",
+				},
+			})
+		} else {
+			batchRequest.Requests = append(batchRequest.Requests, &sheets.Request{
+				AddNamedRange: &sheets.AddNamedRangeRequest{
+					NamedRange: &sheets.NamedRange{Name: name, Range: gridRange},
+				},
+			})
+		}
+	}
+	if _, err := sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, batchRequest).Do(); err != nil {
+		return fmt.Errorf("unable to set named ranges: %v", err)
+	}
+	return nil
+}
+
+func createJurorSheets(ctx context.Context, services *GoogleServices, adminSheetID, newFolderID string, competition Competition, sheetNames []string, pointsAndTotal []RowColumnInfo, logStatus func(message string)) ([]string, error) {
 	logStatus("Creating the spreadsheet for each juror...\n")
 	jurorSheets := []string{}
 	for i, juror := range competition.Jury {
 
 		if err := checkContext(ctx); err != nil {
-			return err
+			return nil, err
 		}
 
 		newFile := &drive.File{
@@ -272,23 +561,156 @@ func createJurorSheets(ctx context.Context, services *GoogleServices, adminSheet
 		}
 		copiedFile, err := services.Drive.Files.Copy(adminSheetID, newFile).Do()
 		if err != nil {
-			return fmt.Errorf("unable to copy spreadsheet: %v", err)
+			return nil, fmt.Errorf("unable to copy spreadsheet: %v", err)
 		}
 		jurorSheets = append(jurorSheets, copiedFile.Id)
 		logStatus(fmt.Sprintf("Copied Overview spreadsheet for Juror #%d (%s) (Sheet ID %s)\n", i+1, juror.Name, copiedFile.Id))
 	}
 
 	if err := checkContext(ctx); err != nil {
-		return err
+		return nil, err
+	}
+	if err := processJurorRows(ctx, services.Sheets, adminSheetID, sheetNames, pointsAndTotal, competition.Jury, effectiveJurorShares(competition.Jury, competition.Criteria), jurorSheets, logStatus); err != nil {
+		return nil, err
+	}
+	if aggregationMode(competition) != aggregationWeightedMean || len(competition.Criteria) > 0 {
+		if err := checkContext(ctx); err != nil {
+			return nil, err
+		}
+		if err := addAggregationFormulas(ctx, services.Sheets, adminSheetID, sheetNames, pointsAndTotal, competition, logStatus); err != nil {
+			return nil, err
+		}
+	}
+	return jurorSheets, nil
+}
+
+// addAggregationFormulas writes the result of every contestant tab in the Overview for the
+// aggregation modes the template formulas cannot express. Right of the juror rows of the first
+// "Points:" row, each juror gets their score (the sum of their totals) and, for the sum of
+// ranks, their placing of the contestant. The result follows in the first juror row, right
+// of a "Result:" label. Must run after processJurorRows, as it addresses the final rows.
+//
+// With criteria, the weighted mean cannot be taken of the juror totals, as a juror's share
+// differs per criterion. Each juror's score then adds up their points per criterion times
+// their effective weight in it and the criterion weight, from the 'Weights' sheet, like
+// computeStandings does.
+func addAggregationFormulas(ctx context.Context, sheetsService *sheets.Service, spreadsheetID string, sheetNames []string, pointsData []RowColumnInfo, competition Competition, logStatus func(message string)) error {
+	if len(pointsData) == 0 || len(competition.Jury) == 0 {
+		return nil
+	}
+	logStatus(fmt.Sprintf("Adding %s formulas to the Overview spreadsheet...\n", strings.ToLower(aggregationName(aggregationMode(competition)))))
+
+	sheetMetadata, err := sheetsService.Spreadsheets.Get(spreadsheetID).Fields("sheets(properties(sheetId,title))").Do()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet metadata: %v", err)
 	}
-	processJurorRows(ctx, services.Sheets, adminSheetID, sheetNames, pointsAndTotal, competition.Jury, jurorSheets, logStatus)
+	sheetNameToID := map[string]int64{}
+	for _, sheet := range sheetMetadata.Sheets {
+		sheetNameToID[sheet.Properties.Title] = sheet.Properties.SheetId
+	}
+
+	// Each "Points:" row was expanded to one row per juror
+	jurorCount := len(competition.Jury)
+	jurorRow := func(p, j int) int { // 0-based
+		return pointsData[p].Row - 1 + p*(jurorCount-1) + j
+	}
+	cellName := func(col, row int) string { // 0-based column and row
+		return fmt.Sprintf("%s%d", columnIndexToLetter(col+1), row+1)
+	}
+	scoreCol := columnLetterToIndex(pointsData[0].EndColumn) + 4 // Two columns right of the feedback
+	rankCol := scoreCol + 1
+	labelCol := scoreCol + 2
+
+	// The "Points:" row and 0-based column of each criterion's points, matched like the
+	// standings match them (see criterionPoints)
+	weighted := aggregationMode(competition) == aggregationWeightedMean
+	matrix := effectiveJurorWeights(competition.Jury, competition.Criteria)
+	var labels []string
+	var positions [][2]int
+	for p, info := range pointsData {
+		for i, label := range info.Labels {
+			labels = append(labels, label)
+			positions = append(positions, [2]int{p, i + 1})
+		}
+	}
+	criterionPositions := matchCriteria(labels, competition.Criteria)
+
+	for _, sheetName := range sheetNames {
+		if err := checkContext(ctx); err != nil {
+			return err
+		}
+		sheetID, exists := sheetNameToID[sheetName]
+		if !exists {
+			return fmt.Errorf("sheet with name %s not found in spreadsheet", sheetName)
+		}
+
+		batchRequest := &sheets.BatchUpdateSpreadsheetRequest{Requests: []*sheets.Request{}}
+		if labelRow := jurorRow(0, 0) - 1; labelRow >= 0 {
+			batchRequest.Requests = append(batchRequest.Requests, createBoldUpdateRequest(sheetID, int64(labelRow), int64(scoreCol), "Score"))
+			if aggregationMode(competition) == aggregationRankSum {
+				batchRequest.Requests = append(batchRequest.Requests, createBoldUpdateRequest(sheetID, int64(labelRow), int64(rankCol), "Rank"))
+			}
+		}
+
+		var aggregated []string
+		for j, juror := range competition.Jury {
+			// The juror's score adds up their totals of all "Points:" rows
+			var totals []string
+			for p, info := range pointsData {
+				totals = append(totals, cellName(columnLetterToIndex(info.EndColumn)+1, jurorRow(p, j)))
+			}
+			scoreFormula := fmt.Sprintf(`=IF(COUNT(%s)=0; ""; SUM(%s))`, strings.Join(totals, "; "), strings.Join(totals, "; "))
+			if weighted {
+				var terms []string
+				for c, i := range criterionPositions {
+					if matrix[j][c] > 0 && i >= 0 {
+						points := cellName(positions[i][1], jurorRow(positions[i][0], j))
+						terms = append(terms, fmt.Sprintf("N(%s)*%s*%s", points, weightMatrixCell(j, c), criterionWeightCell(jurorCount, c)))
+					}
+				}
+				if len(terms) == 0 {
+					continue
+				}
+				scoreFormula = fmt.Sprintf(`=IF(COUNT(%s)=0; ""; %s)`, strings.Join(totals, "; "), strings.Join(terms, " + "))
+			}
+			batchRequest.Requests = append(batchRequest.Requests,
+				createUpdateRequest(sheetID, int64(jurorRow(0, j)), int64(scoreCol), scoreFormula, "userEnteredValue"))
+
+			score := cellName(scoreCol, jurorRow(0, j))
+			if aggregationMode(competition) == aggregationRankSum {
+				// The juror's placing among all contestants, equal scores sharing the mean place
+				var others []string
+				for _, other := range sheetNames {
+					others = append(others, fmt.Sprintf("'%s'!%s", strings.ReplaceAll(other, "'", "''"), score))
+				}
+				rankFormula := fmt.Sprintf(`=IFERROR(RANK.AVG(%s; {%s}; 0); "")`, score, strings.Join(others, ";"))
+				batchRequest.Requests = append(batchRequest.Requests,
+					createUpdateRequest(sheetID, int64(jurorRow(0, j)), int64(rankCol), rankFormula, "userEnteredValue"))
+				score = cellName(rankCol, jurorRow(0, j))
+			}
+			if juror.Weight > 0 {
+				aggregated = append(aggregated, score)
+			}
+		}
+
+		batchRequest.Requests = append(batchRequest.Requests,
+			createBoldUpdateRequest(sheetID, int64(jurorRow(0, 0)), int64(labelCol), "Result:"),
+			createUpdateRequest(sheetID, int64(jurorRow(0, 0)), int64(labelCol+1), aggregationFormula(competition, aggregated), "userEnteredValue"))
+
+		if _, err := sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, batchRequest).Do(); err != nil {
+			return fmt.Errorf("failed to add aggregation formulas to sheet %s: %w", sheetName, err)
+		}
+	}
+
+	logStatus("Aggregation formulas added to the Overview spreadsheet.\n")
 	return nil
 }
 
 // Supporting structs
 type RowColumnInfo struct {
-	Row       int    // Row index (1-based)
-	EndColumn string // Column letter with "Total:" (e.g., "B", "C"), or empty if not found
+	Row       int      `json:"row"`              // Row index (1-based)
+	EndColumn string   `json:"end_column"`       // Column letter with "Total:" (e.g., "B", "C"), or empty if not found
+	Labels    []string `json:"labels,omitempty"` // Criterion names above the points, from column B
 }
 
 type GoogleServices struct {
@@ -303,6 +725,7 @@ func processJurorRows(
 	sheetNames []string,
 	pointsData []RowColumnInfo,
 	jurors []*Juror,
+	jurorShares []float64,
 	jurorSheetIDs []string,
 	logStatus func(message string),
 ) error {
@@ -411,9 +834,9 @@ func processJurorRows(
 				batchRequest.Requests = append(batchRequest.Requests,
 					createUpdateRequest(sheetID, rowOffset, int64(columnLetterToIndex(rowInfo.EndColumn)+3), feedbackFormula, "userEnteredValue"))
 
-				// Juror's weight
+				// Juror's effective weight
 				batchRequest.Requests = append(batchRequest.Requests,
-					createUpdateRequest(sheetID, rowOffset, int64(columnLetterToIndex(rowInfo.EndColumn)+2), float64(juror.Weight)/100, "userEnteredValue"))
+					createUpdateRequest(sheetID, rowOffset, int64(columnLetterToIndex(rowInfo.EndColumn)+2), jurorShares[jurorIndex], "userEnteredValue"))
 			}
 
 			// Execute batch request
@@ -459,6 +882,15 @@ func createUpdateRequest(sheetID int64, rowIndex, colIndex int64, value interfac
 	}
 }
 
+// createBoldUpdateRequest creates a Sheets API update request writing bold text to a specific cell
+func createBoldUpdateRequest(sheetID int64, rowIndex, colIndex int64, text string) *sheets.Request {
+	request := createUpdateRequest(sheetID, rowIndex, colIndex, text, "userEnteredValue,userEnteredFormat.textFormat.bold")
+	request.UpdateCells.Rows[0].Values[0].UserEnteredFormat = &sheets.CellFormat{
+		TextFormat: &sheets.TextFormat{Bold: true},
+	}
+	return request
+}
+
 func findPointsAndTotalTokens(sheetsService *sheets.Service, spreadsheetID, sheetName string) ([]RowColumnInfo, error) {
 	// Range to scan: Rows 1-200, Columns A-Z
 	readRange := fmt.Sprintf("%s!A1:Z200", sheetName)
@@ -477,11 +909,23 @@ func findPointsAndTotalTokens(sheetsService *sheets.Service, spreadsheetID, shee
 			// Search for "Total:" in the row (columns B-Z)
 			for colIndex := 1; colIndex < len(row) && colIndex <= 25; colIndex++ {
 				if row[colIndex] == "Total:" {
-					info.EndColumn = string('A' + colIndex - 1) // Convert to column letter
+					info.EndColumn = string(rune('A' + colIndex - 1)) // Convert to column letter
 					break
 				}
 			}
 
+			// Criterion names from the row above the points
+			if info.EndColumn != "" && rowIndex > 0 {
+				above := resp.Values[rowIndex-1]
+				for colIndex := 1; colIndex < columnLetterToIndex(info.EndColumn); colIndex++ {
+					label := ""
+					if colIndex < len(above) {
+						label = fmt.Sprint(above[colIndex])
+					}
+					info.Labels = append(info.Labels, label)
+				}
+			}
+
 			results = append(results, info)
 		}
 	}
@@ -489,6 +933,30 @@ func findPointsAndTotalTokens(sheetsService *sheets.Service, spreadsheetID, shee
 	return results, nil
 }
 
+// readContestantTabs reads the contestant tabs of a spreadsheet down to lastRow, in the order of tabs
+func readContestantTabs(ctx context.Context, sheetsService *sheets.Service, spreadsheetID string, tabs []ContestantTab, lastRow int) ([][][]interface{}, error) {
+	if len(tabs) == 0 {
+		return nil, nil
+	}
+	ranges := make([]string, len(tabs))
+	for i, tab := range tabs {
+		ranges[i] = fmt.Sprintf("'%s'!A1:Z%d", tab.Sheet, lastRow)
+	}
+
+	resp, err := sheetsService.Spreadsheets.Values.BatchGet(spreadsheetID).Ranges(ranges...).ValueRenderOption("UNFORMATTED_VALUE").Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spreadsheet %s: %v", spreadsheetID, err)
+	}
+	if len(resp.ValueRanges) != len(ranges) {
+		return nil, fmt.Errorf("spreadsheet %s returned %d of %d tabs", spreadsheetID, len(resp.ValueRanges), len(ranges))
+	}
+	values := make([][][]interface{}, len(ranges))
+	for i, valueRange := range resp.ValueRanges {
+		values[i] = valueRange.Values
+	}
+	return values, nil
+}
+
 func columnLetterToIndex(col string) int {
 	result := 0
 	for _, char := range col {
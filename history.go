@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	historyDir       = "history"                // Subfolder of the store directory holding previous versions
+	historyVersions  = 20                       // Number of versions kept per competition
+	historyTimestamp = "20060102-150405.000000" // Layout of the history file names
+)
+
+// HistoryVersion is a previously saved state of a competition
+type HistoryVersion struct {
+	File  string // File name inside the competition's history folder
+	Saved time.Time
+
+	sequence int // Orders versions saved at the same time, see historyFileName
+}
+
+// historyFileName returns the file name of a version saved at saved. Versions whose times
+// are equal, e.g. as zip archives only keep whole seconds, are numbered from 2 on, so
+// taken is asked until a free name is found.
+func historyFileName(saved time.Time, taken func(file string) bool) string {
+	base := saved.Format(historyTimestamp)
+	file := base + ".json"
+	for sequence := 2; taken(file); sequence++ {
+		file = fmt.Sprintf("%s-%d.json", base, sequence)
+	}
+	return file
+}
+
+// parseHistoryFileName returns the version stored in a history file, or false if the file
+// is not a history file
+func parseHistoryFileName(file string) (HistoryVersion, bool) {
+	if filepath.Ext(file) != ".json" {
+		return HistoryVersion{}, false
+	}
+	stamp, suffix := strings.TrimSuffix(file, ".json"), ""
+	if len(stamp) > len(historyTimestamp) {
+		stamp, suffix = stamp[:len(historyTimestamp)], stamp[len(historyTimestamp):]
+	}
+	sequence := 1
+	if suffix != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(suffix, "-"))
+		if err != nil || number < 2 || !strings.HasPrefix(suffix, "-") {
+			return HistoryVersion{}, false
+		}
+		sequence = number
+	}
+	saved, err := time.ParseInLocation(historyTimestamp, stamp, time.Local)
+	if err != nil {
+		return HistoryVersion{}, false
+	}
+	return HistoryVersion{File: file, Saved: saved, sequence: sequence}, true
+}
+
+// sortHistory sorts versions newest first
+func sortHistory(versions []HistoryVersion) {
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].Saved.Equal(versions[j].Saved) {
+			return versions[i].sequence > versions[j].sequence
+		}
+		return versions[i].Saved.After(versions[j].Saved)
+	})
+}
+
+// competitionHistoryDir returns the history folder of a competition inside a store directory
+func competitionHistoryDir(root, id string) string {
+	return filepath.Join(root, historyDir, id)
+}
+
+// archiveCompetitionVersion copies the file currently stored for a competition into its
+// history folder, unless it is identical to the newest version already kept there.
+func archiveCompetitionVersion(root, id, filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil // First save, nothing to keep
+	}
+	if err != nil {
+		return err
+	}
+
+	dir := competitionHistoryDir(root, id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	versions, err := listHistory(root, id)
+	if err != nil {
+		return err
+	}
+	if len(versions) > 0 {
+		if newest, err := os.ReadFile(filepath.Join(dir, versions[0].File)); err == nil && bytes.Equal(newest, data) {
+			return nil
+		}
+	}
+
+	saved := time.Now()
+	if info, err := os.Stat(filePath); err == nil {
+		saved = info.ModTime()
+	}
+	versionPath := filepath.Join(dir, historyFileName(saved, func(file string) bool {
+		_, err := os.Stat(filepath.Join(dir, file))
+		return err == nil
+	}))
+	if err := writeFileAtomic(versionPath, data, 0644); err != nil {
+		return err
+	}
+	return pruneHistory(root, id)
+}
+
+// listHistory returns the versions kept for a competition, newest first
+func listHistory(root, id string) ([]HistoryVersion, error) {
+	entries, err := os.ReadDir(competitionHistoryDir(root, id))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []HistoryVersion{}
+	for _, entry := range entries {
+		if version, ok := parseHistoryFileName(entry.Name()); ok && !entry.IsDir() {
+			versions = append(versions, version)
+		}
+	}
+	sortHistory(versions)
+	return versions, nil
+}
+
+// pruneHistory removes all but the newest historyVersions versions
+func pruneHistory(root, id string) error {
+	versions, err := listHistory(root, id)
+	if err != nil {
+		return err
+	}
+	for _, version := range versions[min(len(versions), historyVersions):] {
+		if err := os.Remove(filepath.Join(competitionHistoryDir(root, id), version.File)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadHistoryVersion reads a version from the history, upgrading it to the current schema
+func loadHistoryVersion(root, id string, version HistoryVersion) (Competition, error) {
+	if version.File != filepath.Base(version.File) {
+		return Competition{}, fmt.Errorf("invalid history file name '%s'", version.File)
+	}
+	data, err := os.ReadFile(filepath.Join(competitionHistoryDir(root, id), version.File))
+	if err != nil {
+		return Competition{}, fmt.Errorf("failed to read version: %w", err)
+	}
+	comp, _, err := parseCompetition(data)
+	if err != nil {
+		return Competition{}, err
+	}
+	return comp, nil
+}
+
+// diffCompetitions lists the changes needed to get from one competition state to another
+func diffCompetitions(from, to Competition) []string {
+	changes := []string{}
+	if from.Name != to.Name {
+		changes = append(changes, fmt.Sprintf("Name changed from '%s' to '%s'", from.Name, to.Name))
+	}
+	if from.SourceSheetID != to.SourceSheetID {
+		changes = append(changes, "Template sheet changed")
+	}
+
+	// Jurors, matched by name
+	fromJurors := make(map[string]*Juror)
+	for _, juror := range from.Jury {
+		fromJurors[juror.Name] = juror
+	}
+	toJurors := make(map[string]*Juror)
+	for _, juror := range to.Jury {
+		toJurors[juror.Name] = juror
+		old, exists := fromJurors[juror.Name]
+		switch {
+		case !exists:
+			changes = append(changes, fmt.Sprintf("Juror added: %s (%d%%)", juror.Name, juror.Weight))
+		case old.Weight != juror.Weight:
+			changes = append(changes, fmt.Sprintf("Juror changed: %s, weight %d%% → %d%%", juror.Name, old.Weight, juror.Weight))
+		case strings.Join(old.Criteria, ",") != strings.Join(juror.Criteria, ","):
+			changes = append(changes, fmt.Sprintf("Juror changed: %s, scored criteria", juror.Name))
+		}
+	}
+	for _, juror := range from.Jury {
+		if _, exists := toJurors[juror.Name]; !exists {
+			changes = append(changes, fmt.Sprintf("Juror removed: %s", juror.Name))
+		}
+	}
+
+	// Contestants, matched by name
+	fromContestants := make(map[string]*Contestant)
+	for _, contestant := range from.Contestants {
+		fromContestants[contestant.Name] = contestant
+	}
+	toContestants := make(map[string]*Contestant)
+	for _, contestant := range to.Contestants {
+		toContestants[contestant.Name] = contestant
+		old, exists := fromContestants[contestant.Name]
+		switch {
+		case !exists:
+			changes = append(changes, fmt.Sprintf("Contestant added: %s", contestant.Name))
+		case old.Team != contestant.Team:
+			changes = append(changes, fmt.Sprintf("Contestant changed: %s, team '%s' → '%s'", contestant.Name, old.Team, contestant.Team))
+		case formatSlot(old.Slot) != formatSlot(contestant.Slot):
+			changes = append(changes, fmt.Sprintf("Contestant changed: %s, slot %s → %s", contestant.Name, formatSlot(old.Slot), formatSlot(contestant.Slot)))
+		}
+	}
+	for _, contestant := range from.Contestants {
+		if _, exists := toContestants[contestant.Name]; !exists {
+			changes = append(changes, fmt.Sprintf("Contestant removed: %s", contestant.Name))
+		}
+	}
+
+	if len(changes) == 0 && contestantOrder(from.Contestants) != contestantOrder(to.Contestants) {
+		changes = append(changes, "Contestant order changed")
+	}
+	return changes
+}
+
+func formatSlot(slot *TimeSlot) string {
+	if slot == nil || slot.Start.IsZero() {
+		return "unscheduled"
+	}
+	return fmt.Sprintf("%s %s", slot.Sauna, slot.Start.Format(scheduleTimeLayout))
+}
+
+func contestantOrder(contestants []*Contestant) string {
+	names := make([]string, len(contestants))
+	for i, contestant := range contestants {
+		names[i] = contestant.Name
+	}
+	return strings.Join(names, "\n")
+}
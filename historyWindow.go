@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// History Window Function
+func showHistoryWindow(myApp fyne.App, history HistoryStore, current Competition, onRestore func(comp Competition)) {
+	historyWindow := myApp.NewWindow(fmt.Sprintf("History of '%s'", current.Name))
+	historyWindow.Resize(fyne.NewSize(700, 450))
+
+	versions, err := history.History(current.ID)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("Failed to read the history: %w", err), historyWindow)
+	}
+
+	diffText := widget.NewLabel("Select a version to see how it differs from the current competition.")
+	diffText.Wrapping = fyne.TextWrapWord
+
+	var selected *Competition
+	restoreButton := widget.NewButton("Restore", func() {
+		if selected == nil {
+			return
+		}
+		restored := *selected
+		dialog.ShowConfirm("Confirm Restore",
+			"Restore this version? The current state is kept in the history.",
+			func(confirm bool) {
+				if confirm {
+					restored.ID = current.ID
+					onRestore(restored)
+					historyWindow.Close()
+				}
+			},
+			historyWindow,
+		)
+	})
+	restoreButton.Disable()
+
+	versionList := widget.NewList(
+		func() int {
+			return len(versions)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(versions[id].Saved.Format("2006-01-02 15:04:05"))
+		},
+	)
+	versionList.OnSelected = func(id widget.ListItemID) {
+		version, err := history.LoadVersion(current.ID, versions[id])
+		if err != nil {
+			selected = nil
+			restoreButton.Disable()
+			diffText.SetText(fmt.Sprintf("This version cannot be read: %v", err))
+			return
+		}
+		selected = &version
+
+		changes := diffCompetitions(version, current)
+		if len(changes) == 0 {
+			diffText.SetText("This version is identical to the current competition.")
+		} else {
+			diffText.SetText("Changes since this version:\n\n" + strings.Join(changes, "\n"))
+		}
+		restoreButton.Enable()
+	}
+
+	var content fyne.CanvasObject = versionList
+	if len(versions) == 0 {
+		content = widget.NewLabel("No earlier versions have been saved yet.")
+	}
+
+	split := container.NewHSplit(content, container.NewVScroll(diffText))
+	split.Offset = 0.3
+
+	historyWindow.SetContent(container.NewBorder(
+		nil,
+		container.NewHBox(
+			layout.NewSpacer(),
+			widget.NewButton("Close", func() {
+				historyWindow.Close()
+			}),
+			restoreButton,
+		),
+		nil, nil,
+		split,
+	))
+	historyWindow.Show()
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	log "github.com/s00500/env_logger"
+)
+
+const (
+	importContestants = "Contestants"
+	importJurors      = "Jurors"
+	importAppend      = "Append to the current list"
+	importReplace     = "Replace the current list"
+	notImported       = "(not imported)"
+)
+
+// rosterFields returns the fields that can be imported for a target list
+func rosterFields(target string) []RosterField {
+	if target == importJurors {
+		return []RosterField{rosterName, rosterFirstName, rosterLastName, rosterWeight, rosterEmail, rosterClub}
+	}
+	return []RosterField{rosterName, rosterFirstName, rosterLastName, rosterClub, rosterEmail}
+}
+
+// Roster Import Window Function
+func showRosterImport(myApp fyne.App, history *UndoHistory, jurors *[]*Juror, contestants *[]*Contestant, onImported func()) {
+	importWindow := myApp.NewWindow("Import Roster")
+	importWindow.Resize(fyne.NewSize(700, 600))
+
+	var rows [][]string
+	var roster Roster
+	var entries []RosterEntry
+	var duplicates map[int]string
+	mapping := RosterMapping{}
+	updating := false // Set while the widgets are rebuilt, to ignore their change events
+
+	fileLabel := widget.NewLabel("No file selected.")
+	targetRadio := widget.NewRadioGroup([]string{importContestants, importJurors}, nil)
+	targetRadio.Horizontal = true
+	targetRadio.SetSelected(importContestants)
+	headerCheck := widget.NewCheck("First row contains column names", nil)
+	headerCheck.SetChecked(true)
+	modeRadio := widget.NewRadioGroup([]string{importAppend, importReplace}, nil)
+	modeRadio.SetSelected(importAppend)
+	skipDuplicatesCheck := widget.NewCheck("Skip duplicates", nil)
+	skipDuplicatesCheck.SetChecked(true)
+	summaryLabel := widget.NewLabel("")
+	summaryLabel.Wrapping = fyne.TextWrapWord
+	mappingForm := container.NewVBox()
+	var importButton *widget.Button
+
+	// Preview of the entries as they would be imported
+	previewTable := widget.NewTable(
+		func() (int, int) {
+			return len(entries) + 1, 5
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText([]string{"Name", "Club / Team", "Email", "Weight", "Status"}[id.Col])
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			entry := entries[id.Row-1]
+			switch id.Col {
+			case 0:
+				label.SetText(entry.Name)
+			case 1:
+				label.SetText(entry.Club)
+			case 2:
+				label.SetText(entry.Email)
+			case 3:
+				if entry.HasWeight && targetRadio.Selected == importJurors {
+					label.SetText(fmt.Sprintf("%d%%", entry.Weight))
+				} else {
+					label.SetText("")
+				}
+			case 4:
+				label.SetText(duplicates[id.Row-1])
+			}
+		},
+	)
+	previewTable.SetColumnWidth(0, 180)
+	previewTable.SetColumnWidth(1, 120)
+	previewTable.SetColumnWidth(2, 160)
+	previewTable.SetColumnWidth(3, 60)
+	previewTable.SetColumnWidth(4, 150)
+
+	// existingNames returns the names in the list the roster is imported into
+	existingNames := func() []string {
+		names := []string{}
+		if targetRadio.Selected == importJurors {
+			jurorsMutex.RLock()
+			for _, juror := range *jurors {
+				names = append(names, juror.Name)
+			}
+			jurorsMutex.RUnlock()
+		} else {
+			contestantsMutex.RLock()
+			for _, contestant := range *contestants {
+				names = append(names, contestant.Name)
+			}
+			contestantsMutex.RUnlock()
+		}
+		return names
+	}
+
+	// updatePreview reads the entries with the current mapping and checks for duplicates
+	updatePreview := func() {
+		if rows == nil {
+			summaryLabel.SetText("Choose a CSV or XLSX file with one person per row.")
+			importButton.Disable()
+			return
+		}
+		var problems []string
+		entries, problems = rosterEntries(roster, mapping)
+
+		existing := existingNames()
+		if modeRadio.Selected == importReplace {
+			existing = nil
+		}
+		duplicates = findRosterDuplicates(entries, existing)
+		previewTable.Refresh()
+
+		summary := fmt.Sprintf("%d %s found", len(entries), strings.ToLower(targetRadio.Selected))
+		if len(duplicates) > 0 {
+			summary += fmt.Sprintf(", %d duplicate(s)", len(duplicates))
+		}
+		summary += "."
+		if len(problems) > 0 {
+			summary += "\n" + strings.Join(problems, "\n")
+		}
+		summaryLabel.SetText(summary)
+
+		if len(entries) > 0 {
+			importButton.Enable()
+		} else {
+			importButton.Disable()
+		}
+	}
+
+	// updateMapping rebuilds the column selectors for the target list
+	updateMapping := func(guess bool) {
+		columns := []string{notImported}
+		for i, header := range roster.Header {
+			columns = append(columns, fmt.Sprintf("%s: %s", xlsxColumnName(i), header))
+		}
+
+		fields := rosterFields(targetRadio.Selected)
+		if guess {
+			mapping = guessRosterMapping(roster, fields)
+		}
+
+		updating = true
+		mappingForm.RemoveAll()
+		for _, field := range fields {
+			field := field
+			columnSelect := widget.NewSelect(columns, func(selected string) {
+				if updating {
+					return
+				}
+				delete(mapping, field)
+				for i, column := range columns[1:] {
+					if column == selected {
+						mapping[field] = i
+					}
+				}
+				updatePreview()
+			})
+			if column, mapped := mapping[field]; mapped && column < len(roster.Header) {
+				columnSelect.SetSelected(columns[column+1])
+			} else {
+				delete(mapping, field)
+				columnSelect.SetSelected(notImported)
+			}
+			mappingForm.Add(container.NewBorder(nil, nil, widget.NewLabel(string(field)+":"), nil, columnSelect))
+		}
+		updating = false
+		updatePreview()
+	}
+
+	// updateRoster re-reads the rows, for example after the header option changed
+	updateRoster := func() {
+		roster = newRoster(rows, headerCheck.Checked)
+		updateMapping(true)
+	}
+
+	chooseButton := widget.NewButton("Choose File...", func() {
+		fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				if err != nil {
+					log.Printf("File selection error: %v", err)
+				}
+				return
+			}
+			defer reader.Close()
+
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("Failed to read the file: %w", err), importWindow)
+				return
+			}
+			fileRows, err := readRosterRows(data, reader.URI().Extension())
+			if err != nil {
+				dialog.ShowError(err, importWindow)
+				return
+			}
+			rows = fileRows
+			fileLabel.SetText(reader.URI().Name())
+			updateRoster()
+		}, importWindow)
+		fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".txt", ".xlsx"}))
+		fileDialog.Show()
+	})
+
+	targetRadio.OnChanged = func(string) {
+		updateMapping(true)
+	}
+	headerCheck.OnChanged = func(bool) {
+		updateRoster()
+	}
+	modeRadio.OnChanged = func(string) {
+		updatePreview()
+	}
+
+	importButton = widget.NewButton("Import", func() {
+		replace := modeRadio.Selected == importReplace
+		skip := map[int]string{}
+		if skipDuplicatesCheck.Checked {
+			skip = duplicates
+		}
+
+		// The import replaces the whole list, so a single undo restores the list as it was
+		if targetRadio.Selected == importJurors {
+			jurorsMutex.RLock()
+			imported := importRosterJurors(*jurors, entries, skip, replace)
+			jurorsMutex.RUnlock()
+			history.Execute(replaceListCommand("Import Jurors", jurors, &jurorsMutex, imported, onImported))
+		} else {
+			contestantsMutex.RLock()
+			imported := importRosterContestants(*contestants, entries, skip, replace)
+			contestantsMutex.RUnlock()
+			history.Execute(replaceListCommand("Import Contestants", contestants, &contestantsMutex, imported, onImported))
+		}
+		log.Printf("Imported %d %s from %s", len(entries)-len(skip), strings.ToLower(targetRadio.Selected), fileLabel.Text)
+		importWindow.Close()
+	})
+	importButton.Disable()
+
+	updateRoster()
+
+	importWindow.SetContent(container.NewBorder(
+		container.NewVBox(
+			container.NewBorder(nil, nil, nil, chooseButton, fileLabel),
+			container.NewHBox(widget.NewLabel("Import as:"), targetRadio),
+			headerCheck,
+			widget.NewLabelWithStyle("Columns:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			mappingForm,
+			widget.NewLabelWithStyle("Preview:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		),
+		container.NewVBox(
+			summaryLabel,
+			modeRadio,
+			skipDuplicatesCheck,
+			container.NewHBox(
+				layout.NewSpacer(),
+				widget.NewButton("Cancel", func() {
+					importWindow.Close()
+				}),
+				importButton,
+			),
+		),
+		nil, nil,
+		previewTable,
+	))
+	importWindow.Show()
+}
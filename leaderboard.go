@@ -0,0 +1,41 @@
+package main
+
+import "fmt"
+
+// LeaderboardEntry is one line of the leaderboard
+type LeaderboardEntry struct {
+	Rank       string
+	Contestant string
+	Total      string
+	Gap        string // Distance to the place above
+}
+
+// leaderboardEntries lists the standings for the leaderboard. The gap is the distance to the
+// next better placing; contestants sharing a placing are marked as tied, and contestants
+// placed by a tie-break rule show the rule's decision instead of a gap of zero.
+func leaderboardEntries(standings *Standings) []LeaderboardEntry {
+	var entries []LeaderboardEntry
+	for i, result := range standings.Results {
+		entry := LeaderboardEntry{
+			Rank:       fmt.Sprintf("%d.", result.Rank),
+			Contestant: result.Contestant,
+			Total:      formatScore(result.Total),
+		}
+		if i > 0 {
+			above := standings.Results[i-1]
+			switch {
+			case above.Rank == result.Rank:
+				entry.Rank = ""
+				entry.Gap = "tied"
+			case roundScore(above.Total) == roundScore(result.Total):
+				entry.Gap = "tie-break"
+			case standings.LowerIsBetter:
+				entry.Gap = "+" + formatScore(roundScore(result.Total)-roundScore(above.Total))
+			default:
+				entry.Gap = "-" + formatScore(roundScore(above.Total)-roundScore(result.Total))
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	log "github.com/s00500/env_logger"
+)
+
+const presentationTextScale = 2.5 // Text size of the presentation mode relative to the theme
+
+// Leaderboard Window Function
+func showLeaderboard(myApp fyne.App, comp Competition) {
+	leaderboardWindow := myApp.NewWindow(fmt.Sprintf("Leaderboard of '%s'", comp.Name))
+	leaderboardWindow.Resize(fyne.NewSize(700, 600))
+	manifest := comp.Generation
+
+	// latest is the newest ranking, shown is the one on display, which stays while frozen
+	var standingsMutex sync.Mutex
+	var latest, shown *Standings
+	var shownAt time.Time
+	frozen := false
+	presenting := false
+
+	board := container.NewVBox()
+	rebuildBoard := func() {
+		standingsMutex.Lock()
+		displayed, large := shown, presenting
+		standingsMutex.Unlock()
+
+		textSize := theme.TextSize()
+		if large {
+			textSize *= presentationTextScale
+		}
+		newText := func(text string, alignment fyne.TextAlign, bold bool) *canvas.Text {
+			t := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+			t.TextSize = textSize
+			t.Alignment = alignment
+			t.TextStyle = fyne.TextStyle{Bold: bold}
+			return t
+		}
+		fixed := func(sample string, object fyne.CanvasObject) fyne.CanvasObject {
+			size := fyne.MeasureText(sample, textSize, fyne.TextStyle{Bold: true})
+			return container.NewGridWrap(fyne.NewSize(size.Width, size.Height), object)
+		}
+
+		board.RemoveAll()
+		title := newText(comp.Name, fyne.TextAlignCenter, true)
+		title.TextSize = textSize * 1.4
+		board.Add(title)
+		if displayed == nil {
+			board.Add(newText("No results yet", fyne.TextAlignCenter, false))
+			board.Refresh()
+			return
+		}
+		board.Add(container.NewBorder(nil, nil,
+			fixed("000.", newText("", fyne.TextAlignTrailing, true)),
+			container.NewHBox(
+				fixed("0000.00", newText(displayed.totalLabel(), fyne.TextAlignTrailing, true)),
+				fixed("tie-break", newText("Gap", fyne.TextAlignTrailing, true)),
+			),
+			newText("Contestant", fyne.TextAlignLeading, true),
+		))
+		for _, entry := range leaderboardEntries(displayed) {
+			board.Add(container.NewBorder(nil, nil,
+				fixed("000.", newText(entry.Rank, fyne.TextAlignTrailing, true)),
+				container.NewHBox(
+					fixed("0000.00", newText(entry.Total, fyne.TextAlignTrailing, true)),
+					fixed("tie-break", newText(entry.Gap, fyne.TextAlignTrailing, false)),
+				),
+				newText(entry.Contestant, fyne.TextAlignLeading, false),
+			))
+		}
+		board.Refresh()
+	}
+
+	statusLabel := widget.NewLabel("")
+	statusMessage := ""
+	updateStatus := func(message string) {
+		standingsMutex.Lock()
+		defer standingsMutex.Unlock()
+		if message != "" {
+			statusMessage = message
+		}
+		if frozen {
+			statusLabel.SetText(fmt.Sprintf("%s - display frozen at %s", statusMessage, shownAt.Local().Format("15:04:05")))
+			return
+		}
+		statusLabel.SetText(statusMessage)
+	}
+
+	// Show the cached results until the first refresh
+	if cached, err := loadCachedResults(comp.ID); err != nil {
+		log.Printf("Failed to read cached results: %v", err)
+	} else if cached != nil {
+		latest = computeStandings(comp, cached)
+		shown, shownAt = latest, cached.FetchedAt
+	}
+	rebuildBoard()
+
+	interval := refreshIntervals[0].Interval
+	refresh := func(bool) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		fetched, err := fetchResults(ctx, myApp.Preferences().String("credentials"), manifest, func(message string) {
+			updateStatus(strings.TrimSpace(message))
+		})
+		if err != nil {
+			log.Printf("Failed to read the juror spreadsheets: %v", err)
+			updateStatus(fmt.Sprintf("Failed to read the juror spreadsheets: %v", err))
+			return
+		}
+		if err := cacheResults(comp.ID, fetched); err != nil {
+			log.Printf("Failed to cache results: %v", err)
+		}
+		standings := computeStandings(comp, fetched)
+		standingsMutex.Lock()
+		latest = standings
+		changed := !frozen
+		if changed {
+			shown, shownAt = standings, fetched.FetchedAt
+		}
+		standingsMutex.Unlock()
+		updateStatus(fmt.Sprintf("Last update %s", fetched.FetchedAt.Local().Format("15:04:05")))
+		if changed {
+			rebuildBoard()
+		}
+	}
+	refreshNow, stop := pollResults(func() time.Duration {
+		standingsMutex.Lock()
+		defer standingsMutex.Unlock()
+		return interval
+	}, refresh)
+	leaderboardWindow.SetOnClosed(stop)
+
+	intervalNames := []string{}
+	for _, option := range refreshIntervals {
+		intervalNames = append(intervalNames, option.Name)
+	}
+	intervalSelect := widget.NewSelect(intervalNames, func(name string) {
+		for _, option := range refreshIntervals {
+			if option.Name == name {
+				standingsMutex.Lock()
+				interval = option.Interval
+				standingsMutex.Unlock()
+			}
+		}
+	})
+	intervalSelect.Selected = refreshIntervals[0].Name
+
+	// Frozen, the display keeps its ranking while the results are still read in the background
+	freezeCheck := widget.NewCheck("Freeze display", func(checked bool) {
+		standingsMutex.Lock()
+		frozen = checked
+		if !frozen {
+			shown = latest
+		}
+		standingsMutex.Unlock()
+		updateStatus("")
+		rebuildBoard()
+	})
+
+	var controls *fyne.Container
+	setPresenting := func(on bool) {
+		standingsMutex.Lock()
+		presenting = on
+		standingsMutex.Unlock()
+		leaderboardWindow.SetFullScreen(on)
+		if on {
+			controls.Hide()
+		} else {
+			controls.Show()
+		}
+		rebuildBoard()
+	}
+	leaderboardWindow.Canvas().SetOnTypedKey(func(event *fyne.KeyEvent) {
+		if event.Name == fyne.KeyEscape {
+			setPresenting(false)
+		}
+	})
+
+	controls = container.NewVBox(
+		container.NewBorder(nil, nil, nil,
+			container.NewHBox(
+				widget.NewLabel("Every"), intervalSelect,
+				freezeCheck,
+				widget.NewButton("Refresh Now", refreshNow),
+				widget.NewButton("Present", func() {
+					setPresenting(true)
+				}),
+			),
+			statusLabel,
+		),
+		widget.NewLabel("Present shows the leaderboard full screen for a projector. Press Escape to leave it."),
+	)
+
+	leaderboardWindow.SetContent(container.NewBorder(
+		controls,
+		nil, nil, nil,
+		container.NewVScroll(container.NewPadded(container.NewVBox(board, layout.NewSpacer()))),
+	))
+	leaderboardWindow.Show()
+}
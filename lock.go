@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+const scoringLockDescription = "Scoring locked" // Description of the protected ranges added by Lock Scoring
+
+// ScoringLockEvent records who locked or unlocked the juror spreadsheets and when
+type ScoringLockEvent struct {
+	Locked bool      `json:"locked"` // True for locking, false for unlocking
+	By     string    `json:"by"`
+	At     time.Time `json:"at"`
+	Failed []string  `json:"failed,omitempty"` // Jurors whose spreadsheets could not be changed
+}
+
+// String describes the event for display
+func (e ScoringLockEvent) String() string {
+	action := "Unlocked"
+	if e.Locked {
+		action = "Locked"
+	}
+	description := fmt.Sprintf("%s by %s on %s", action, e.By, e.At.Local().Format("2006-01-02 15:04"))
+	if len(e.Failed) > 0 {
+		description += fmt.Sprintf(", except the spreadsheets of %s", strings.Join(e.Failed, ", "))
+	}
+	return description
+}
+
+// scoringLocked returns the last lock event and whether scoring is locked
+func (c Competition) scoringLocked() (ScoringLockEvent, bool) {
+	if len(c.ScoringLog) == 0 {
+		return ScoringLockEvent{}, false
+	}
+	last := c.ScoringLog[len(c.ScoringLog)-1]
+	return last, last.Locked
+}
+
+// lockOperator names the person locking or unlocking: the user of this computer and the
+// service account the spreadsheets are changed with
+func lockOperator(credentials string) string {
+	name := os.Getenv("USER")
+	if current, err := user.Current(); err == nil {
+		name = current.Username
+		if current.Name != "" {
+			name = current.Name
+		}
+	}
+	var account struct {
+		ClientEmail string `json:"client_email"`
+	}
+	if err := json.Unmarshal([]byte(credentials), &account); err == nil && account.ClientEmail != "" {
+		if name == "" {
+			return account.ClientEmail
+		}
+		return fmt.Sprintf("%s (%s)", name, account.ClientEmail)
+	}
+	if name == "" {
+		return "unknown"
+	}
+	return name
+}
+
+// recordScoringLock adds a lock event to the stored competition
+func recordScoringLock(id string, event ScoringLockEvent) error {
+	comp, err := store.Load(id)
+	if err != nil {
+		return err
+	}
+	comp.ScoringLog = append(comp.ScoringLog, event)
+	return store.Save(comp)
+}
+
+// setScoringLock protects the contestant tabs of every juror spreadsheet so only the owner can
+// edit them, or removes that protection again. Spreadsheets that already are in the requested
+// state are left alone, so a failed run can be repeated. Failures of single spreadsheets are
+// collected and returned after all spreadsheets were tried, together with the jurors whose
+// spreadsheets were not changed. If the run is cancelled, the remaining spreadsheets count
+// as not changed.
+func setScoringLock(ctx context.Context, credentials string, manifest *GenerationManifest, lock bool, logStatus func(message string)) ([]string, error) {
+	if manifest.SpreadsheetCount() == 0 {
+		return nil, fmt.Errorf("The competition has not been generated yet.")
+	}
+	services, err := initializeGoogleServices(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	contestantSheets := make(map[string]bool)
+	for _, tab := range manifest.Tabs {
+		contestantSheets[tab.Sheet] = true
+	}
+
+	var failed, failures []string
+	for i, sheet := range manifest.JurorSheets {
+		err := checkContext(ctx)
+		if err == nil {
+			if lock {
+				logStatus(fmt.Sprintf("Locking the spreadsheet of Juror #%d (%s)...\n", i+1, sheet.Juror))
+			} else {
+				logStatus(fmt.Sprintf("Unlocking the spreadsheet of Juror #%d (%s)...\n", i+1, sheet.Juror))
+			}
+			if err = setSpreadsheetLock(services.Sheets, sheet.SpreadsheetID, contestantSheets, lock); err != nil {
+				logStatus(fmt.Sprintf("Error: %v\n", err))
+			}
+		}
+		if err != nil {
+			failed = append(failed, sheet.Juror)
+			failures = append(failures, fmt.Sprintf("%s: %v", sheet.Juror, err))
+		}
+	}
+	if len(failures) > 0 {
+		return failed, fmt.Errorf("%d of %d spreadsheets could not be changed:\n%s", len(failures), len(manifest.JurorSheets), strings.Join(failures, "\n"))
+	}
+	logStatus("Finished.\n")
+	return nil, nil
+}
+
+// setSpreadsheetLock adds or removes the scoring lock of the contestant tabs of one spreadsheet
+func setSpreadsheetLock(sheetsService *sheets.Service, spreadsheetID string, contestantSheets map[string]bool, lock bool) error {
+	spreadsheet, err := sheetsService.Spreadsheets.Get(spreadsheetID).Fields("sheets(properties(sheetId,title),protectedRanges(protectedRangeId,description))").Do()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve sheet metadata: %v", err)
+	}
+
+	batchRequest := &sheets.BatchUpdateSpreadsheetRequest{Requests: []*sheets.Request{}}
+	for _, sheet := range spreadsheet.Sheets {
+		if !contestantSheets[sheet.Properties.Title] {
+			continue
+		}
+		locked := false
+		for _, protected := range sheet.ProtectedRanges {
+			if protected.Description != scoringLockDescription {
+				continue
+			}
+			locked = true
+			if !lock {
+				batchRequest.Requests = append(batchRequest.Requests, &sheets.Request{
+					DeleteProtectedRange: &sheets.DeleteProtectedRangeRequest{ProtectedRangeId: protected.ProtectedRangeId},
+				})
+			}
+		}
+		if lock && !locked {
+			// Without editors, only the owner of the spreadsheet keeps edit access
+			batchRequest.Requests = append(batchRequest.Requests, &sheets.Request{
+				AddProtectedRange: &sheets.AddProtectedRangeRequest{
+					ProtectedRange: &sheets.ProtectedRange{
+						Range:       &sheets.GridRange{SheetId: sheet.Properties.SheetId},
+						Description: scoringLockDescription,
+					},
+				},
+			})
+		}
+	}
+	if len(batchRequest.Requests) == 0 {
+		return nil
+	}
+	if _, err := sheetsService.Spreadsheets.BatchUpdate(spreadsheetID, batchRequest).Do(); err != nil {
+		return fmt.Errorf("unable to update protected ranges: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Scoring Lock Dialog Function
+//
+// The lock event is stored in the competition as soon as the spreadsheets were changed, even if
+// some of them failed. onStored is then called on the worker goroutine, and onRecorded on the
+// UI thread once the outcome was shown.
+func showScoringLock(myApp fyne.App, parent fyne.Window, comp Competition, lock bool, onStored func(), onRecorded func(event ScoringLockEvent)) {
+	title, action := "Unlock Scoring", "Unlock"
+	message := "Jurors will be able to edit their scores again."
+	if lock {
+		title, action = "Lock Scoring", "Lock"
+		message = "The contestant tabs of every juror spreadsheet will be protected, so jurors can no longer change their scores."
+	}
+	if last, _ := comp.scoringLocked(); !last.At.IsZero() {
+		message += fmt.Sprintf("\n\n%s.", last)
+	}
+
+	dialog.ShowConfirm(title, message+"\n\nContinue?", func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		progress := widget.NewLabel("")
+		progress.Wrapping = fyne.TextWrapWord
+		progressDialog := dialog.NewCustomWithoutButtons(title, progress, parent)
+		progressDialog.Resize(fyne.NewSize(450, 150))
+		progressDialog.Show()
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+			credentials := myApp.Preferences().String("credentials")
+			failed, err := setScoringLock(ctx, credentials, comp.Generation, lock, func(message string) {
+				progress.SetText(strings.TrimSpace(message))
+			})
+			progressDialog.Hide()
+			if err != nil && (len(failed) == 0 || len(failed) == len(comp.Generation.JurorSheets)) {
+				dialog.ShowError(fmt.Errorf("Failed to %s scoring: %w", strings.ToLower(action), err), parent)
+				return
+			}
+
+			// A partial outcome is recorded as well, so the competition matches the spreadsheets
+			event := ScoringLockEvent{Locked: lock, By: lockOperator(credentials), At: time.Now().UTC(), Failed: failed}
+			if err := recordScoringLock(comp.ID, event); err != nil {
+				dialog.ShowError(fmt.Errorf("The spreadsheets were changed, but recording it in the competition failed: %w", err), parent)
+				return
+			}
+			onStored()
+
+			var result dialog.Dialog
+			if err != nil {
+				result = dialog.NewError(fmt.Errorf("Failed to %s scoring: %w\n\nThe other spreadsheets were %sed, which is recorded in the competition. Choose %s again to retry.",
+					strings.ToLower(action), err, strings.ToLower(action), title), parent)
+			} else {
+				result = dialog.NewInformation(title, fmt.Sprintf("%s.", event), parent)
+			}
+			result.SetOnClosed(func() {
+				onRecorded(event)
+			})
+			result.Show()
+		}()
+	}, parent)
+}
@@ -27,65 +27,90 @@ const (
 )
 
 type Competition struct {
-	Name          string        `json:"name"`
-	SourceSheetID string        `json:"source_sheet_id"`
-	Jury          []*Juror      `json:"jury"`
-	Contestants   []*Contestant `json:"contestants"`
+	SchemaVersion int                 `json:"schema_version"`
+	ID            string              `json:"id"`
+	Name          string              `json:"name"`
+	Event         EventMetadata       `json:"event"`
+	SourceSheetID string              `json:"source_sheet_id"`
+	Jury          []*Juror            `json:"jury"`
+	Contestants   []*Contestant       `json:"contestants"`
+	Schedule      Schedule            `json:"schedule"`
+	Draws         []*DrawRecord       `json:"draws,omitempty"`
+	Criteria      []*Criterion        `json:"criteria,omitempty"`
+	PointsRange   *PointsRange        `json:"points_range,omitempty"` // Points a juror may give per criterion, checked by the scoring monitor
+	Aggregation   string              `json:"aggregation,omitempty"`  // How juror scores are combined, empty for the weighted mean
+	TrimCount     int                 `json:"trim_count,omitempty"`   // Scores the trimmed mean drops at each end
+	TieBreaks     []TieBreakRule      `json:"tie_breaks,omitempty"`   // Applied in order to contestants with the same total
+	ScoringLog    []ScoringLockEvent  `json:"scoring_log,omitempty"`  // Locking and unlocking of the juror spreadsheets, oldest first
+	Generation    *GenerationManifest `json:"generation,omitempty"`   // Spreadsheets of the last generation run
 }
 
 type Juror struct {
-	Name   string `json:"name"`
-	Weight int    `json:"weight"`
+	Name     string   `json:"name"`
+	Weight   int      `json:"weight"`
+	Email    string   `json:"email,omitempty"`
+	Club     string   `json:"club,omitempty"`
+	Criteria []string `json:"criteria,omitempty"` // Criteria scored by this juror, empty for all
 }
 
 type Contestant struct {
-	Name string `json:"name"`
+	Name  string    `json:"name"`
+	Team  string    `json:"team,omitempty"`
+	Email string    `json:"email,omitempty"`
+	Slot  *TimeSlot `json:"slot,omitempty"`
 }
 
 var dataDir string
 
 func main() {
-	initializeDataDir()
-
 	myApp := app.NewWithID("com.example.aufgussscoring")
 	myApp.Settings().SetTheme(&CustomTheme{})
 
+	initializeDataDir(myApp)
+
 	mainWindow := myApp.NewWindow("Scoring Sheet Generator")
 	mainWindow.Resize(fyne.NewSize(appWidth, 600))
 
 	logo := loadLogo()
 
+	mainLayout, actions := fynelayout(mainWindow, myApp)
 	appLayout := container.NewVBox(
 		spaceAbove(10),
 		logo,
 		spaceAbove(10),
-		fynelayout(mainWindow, myApp),
+		mainLayout,
 	)
 
 	mainWindow.SetContent(appLayout)
-	mainWindow.SetMainMenu(createAppMenu(myApp, mainWindow))
+	mainWindow.SetMainMenu(createAppMenu(myApp, mainWindow, actions))
+	registerUndoShortcuts(mainWindow, actions.History)
+	offerLegacyMigration(myApp, mainWindow, actions.RefreshCompetitions)
+	actions.RecoverDrafts()
+	mainWindow.SetOnClosed(actions.SaveDraft)
 	mainWindow.ShowAndRun()
 }
 
-func initializeDataDir() {
-	execPath, err := getExecutableDir()
+func initializeDataDir(myApp fyne.App) {
+	var err error
+	dataDir, err = resolveDataDir(myApp)
 	if err != nil {
-		log.Fatalf("Failed to get executable directory: %v", err)
-	}
-
-	exePathParts := strings.Split(execPath, "Aufguss Scoring Generator.app")
-	basePath := ""
-	if len(exePathParts) == 2 {
-		basePath = exePathParts[0]
+		log.Fatalf("Failed to determine data directory: %v", err)
 	}
 
-	dataDir = filepath.Join(basePath, competitionsDir)
 	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
 		if err := os.MkdirAll(dataDir, 0755); err != nil {
 			log.Fatalf("Failed to create competitions directory: %v", err)
 		}
 	}
 	log.Printf("Data directory: %s", dataDir)
+
+	// A portable archive replaces the data directory as competition store if configured
+	if archiveFile := strings.TrimSpace(myApp.Preferences().String(archiveFilePreference)); archiveFile != "" {
+		store = newArchiveStore(filepath.Clean(archiveFile))
+	} else {
+		store = newDirStore(dataDir)
+	}
+	log.Printf("Competitions are stored in %s", store.Location())
 }
 
 func getExecutableDir() (string, error) {
@@ -110,14 +135,58 @@ func loadLogo() *canvas.Image {
 	return logo
 }
 
-func createAppMenu(myApp fyne.App, mainWindow fyne.Window) *fyne.MainMenu {
-	return fyne.NewMainMenu(
+func createAppMenu(myApp fyne.App, mainWindow fyne.Window, actions *layoutActions) *fyne.MainMenu {
+	undoItem := fyne.NewMenuItem("Undo", actions.History.Undo)
+	undoItem.Shortcut = &fyne.ShortcutUndo{}
+	redoItem := fyne.NewMenuItem("Redo", actions.History.Redo)
+	redoItem.Shortcut = redoShortcut
+
+	mainMenu := fyne.NewMainMenu(
 		fyne.NewMenu("",
 			fyne.NewMenuItem("Preferences", func() {
 				showPreferences(myApp, mainWindow)
 			}),
 		),
+		fyne.NewMenu("Edit", undoItem, redoItem),
 	)
+
+	// Name the command that Undo and Redo would affect, and disable them when there is none
+	updateEditMenu := func() {
+		undoName, redoName := actions.History.UndoName(), actions.History.RedoName()
+		undoItem.Label, undoItem.Disabled = "Undo", undoName == ""
+		if undoName != "" {
+			undoItem.Label = "Undo " + undoName
+		}
+		redoItem.Label, redoItem.Disabled = "Redo", redoName == ""
+		if redoName != "" {
+			redoItem.Label = "Redo " + redoName
+		}
+		mainMenu.Refresh()
+	}
+	// The layout hands every change to the autosave, so keep its handler
+	layoutChanged := actions.History.OnChanged
+	actions.History.OnChanged = func() {
+		if layoutChanged != nil {
+			layoutChanged()
+		}
+		updateEditMenu()
+	}
+	updateEditMenu()
+
+	return mainMenu
+}
+
+// registerUndoShortcuts makes Ctrl+Z and Ctrl+Shift+Z work while no entry has the focus
+func registerUndoShortcuts(mainWindow fyne.Window, history *UndoHistory) {
+	mainWindow.Canvas().AddShortcut(&fyne.ShortcutUndo{}, func(fyne.Shortcut) {
+		history.Undo()
+	})
+	mainWindow.Canvas().AddShortcut(&fyne.ShortcutRedo{}, func(fyne.Shortcut) {
+		history.Redo()
+	})
+	mainWindow.Canvas().AddShortcut(redoShortcut, func(fyne.Shortcut) {
+		history.Redo()
+	})
 }
 
 func spaceAbove(height int) *canvas.Rectangle {
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// GenerationManifest records the spreadsheets created by a generation run, so results can
+// be read back and the competition can be handed over to another organizer.
+type GenerationManifest struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	FolderID    string            `json:"folder_id"`
+	OverviewID  string            `json:"overview_id"`
+	Template    TemplateReference `json:"template"`
+	HeaderRows  int               `json:"header_rows"`  // Rows inserted above the template by the event header
+	PointsRows  []RowColumnInfo   `json:"points_rows"`  // "Points:" rows of each contestant tab, before juror rows were inserted
+	Tabs        []ContestantTab   `json:"tabs"`         // Contestant tabs in running order
+	JurorSheets []JurorSheet      `json:"juror_sheets"` // One spreadsheet per juror, in jury order
+}
+
+// TemplateReference identifies the template spreadsheet and the revision that was used
+type TemplateReference struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name,omitempty"`
+	Revision string    `json:"revision,omitempty"`
+	Modified time.Time `json:"modified,omitempty"`
+}
+
+// ContestantTab is the tab holding a contestant's scores in every spreadsheet
+type ContestantTab struct {
+	Contestant string `json:"contestant"`
+	Sheet      string `json:"sheet"`
+}
+
+// JurorSheet is the spreadsheet a juror enters their scores in
+type JurorSheet struct {
+	Juror         string `json:"juror"`
+	SpreadsheetID string `json:"spreadsheet_id"`
+}
+
+// String describes the template reference for display
+func (t TemplateReference) String() string {
+	name := t.Name
+	if name == "" {
+		name = t.ID
+	}
+	if t.Revision != "" {
+		name += fmt.Sprintf(" (revision %s)", t.Revision)
+	}
+	return name
+}
+
+// spreadsheetURL returns the browser URL of a spreadsheet
+func spreadsheetURL(id string) string {
+	return fmt.Sprintf("https://docs.google.com/spreadsheets/d/%s", id)
+}
+
+// SpreadsheetCount returns the number of spreadsheets created by the generation run
+func (m *GenerationManifest) SpreadsheetCount() int {
+	if m == nil || m.OverviewID == "" {
+		return 0
+	}
+	return 1 + len(m.JurorSheets)
+}
+
+// recordGeneration stores the manifest of a generation run in the saved competition
+func recordGeneration(id string, manifest *GenerationManifest) error {
+	comp, err := store.Load(id)
+	if err != nil {
+		return err
+	}
+	comp.Generation = manifest
+	return store.Save(comp)
+}
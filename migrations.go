@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	currentSchemaVersion = 2         // Schema version written by this build
+	backupsDir           = "backups" // Subfolder of the store directory holding originals of migrated files
+)
+
+// migrations[i] upgrades a competition document from schema version i to i+1.
+// Migrations work on the raw JSON document so they do not depend on the current
+// Go structs, which only describe the latest schema.
+var migrations = []func(doc map[string]interface{}) error{
+	migrateV0ToV1,
+	migrateV1ToV2,
+}
+
+// migrateV0ToV1 upgrades files written before schema versioning was introduced
+func migrateV0ToV1(doc map[string]interface{}) error {
+	// Early files could store null for empty lists
+	for _, key := range []string{"jury", "contestants"} {
+		if doc[key] == nil {
+			doc[key] = []interface{}{}
+		}
+	}
+	return nil
+}
+
+// migrateV1ToV2 gives the competition a stable ID. Version 1 files were stored under
+// their name, version 2 files are stored under their ID.
+func migrateV1ToV2(doc map[string]interface{}) error {
+	if id, ok := doc["id"].(string); !ok || id == "" {
+		doc["id"] = newCompetitionID()
+	}
+	return nil
+}
+
+// parseCompetition decodes a competition file of any known schema version and upgrades
+// it to the current one. It returns the version the file was stored with.
+func parseCompetition(data []byte) (Competition, int, error) {
+	var comp Competition
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return comp, 0, fmt.Errorf("the file is not valid JSON: %v", err)
+	}
+	if doc == nil {
+		return comp, 0, fmt.Errorf("the file does not contain a competition")
+	}
+
+	version := 0
+	if raw, exists := doc["schema_version"]; exists {
+		number, ok := raw.(float64)
+		if !ok || number < 0 || number != float64(int(number)) {
+			return comp, 0, fmt.Errorf("invalid schema version %v", raw)
+		}
+		version = int(number)
+	}
+	if version > currentSchemaVersion {
+		return comp, version, fmt.Errorf("the file uses schema version %d, but this version of the app only supports up to %d. Please update the app", version, currentSchemaVersion)
+	}
+
+	for v := version; v < currentSchemaVersion; v++ {
+		if err := migrations[v](doc); err != nil {
+			return comp, version, fmt.Errorf("migration from schema version %d to %d failed: %v", v, v+1, err)
+		}
+		doc["schema_version"] = v + 1
+	}
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return comp, version, fmt.Errorf("failed to serialize migrated competition: %v", err)
+	}
+	if err := json.Unmarshal(upgraded, &comp); err != nil {
+		return comp, version, fmt.Errorf("the file does not match the competition format: %v", err)
+	}
+	return comp, version, nil
+}
+
+// backupCompetitionFile copies a file into the backups folder of root before it is migrated
+func backupCompetitionFile(root, filePath string, version int) (string, error) {
+	backupDir := filepath.Join(root, backupsDir)
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file for backup: %w", err)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	backupPath := filepath.Join(backupDir, fmt.Sprintf("%s.v%d.%s.json", base, version, time.Now().Format("20060102-150405")))
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+	return backupPath, nil
+}
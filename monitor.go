@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PointsRange is the range of points a juror may give per criterion
+type PointsRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// validatePointsRange checks that the points range is not empty
+func validatePointsRange(comp Competition) error {
+	if comp.PointsRange != nil && comp.PointsRange.Min >= comp.PointsRange.Max {
+		return fmt.Errorf("The points range must run from a lower to a higher number, but runs from %s to %s.",
+			formatScore(comp.PointsRange.Min), formatScore(comp.PointsRange.Max))
+	}
+	return nil
+}
+
+// parsePointsRange reads the bounds entered for the points range. Both empty means no range.
+// Decimal commas are accepted.
+func parsePointsRange(minText, maxText string) (*PointsRange, error) {
+	minText, maxText = strings.TrimSpace(minText), strings.TrimSpace(maxText)
+	if minText == "" && maxText == "" {
+		return nil, nil
+	}
+	if minText == "" || maxText == "" {
+		return nil, fmt.Errorf("Enter both Min and Max, or leave both empty for no range.")
+	}
+	min, err := strconv.ParseFloat(strings.ReplaceAll(minText, ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("Min '%s' is not a number.", minText)
+	}
+	max, err := strconv.ParseFloat(strings.ReplaceAll(maxText, ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("Max '%s' is not a number.", maxText)
+	}
+	pointsRange := &PointsRange{Min: min, Max: max}
+	if err := validatePointsRange(Competition{PointsRange: pointsRange}); err != nil {
+		return nil, err
+	}
+	return pointsRange, nil
+}
+
+// ScoreCheck is the scoring progress of one juror for one contestant
+type ScoreCheck struct {
+	Unreadable bool     // The juror spreadsheet could not be read
+	Entered    int      // Points entered of those the juror is expected to give
+	Expected   int      // Points the juror is expected to give
+	OutOfRange []string // Labels of points outside the points range
+	Feedback   bool     // The juror wrote feedback
+}
+
+// checkScores reports what a juror has entered for a contestant. With criteria, jurors are
+// expected to give the points of the criteria they score; otherwise every points cell.
+func checkScores(comp Competition, results *CompetitionResults, juror int, contestant string) ScoreCheck {
+	var check ScoreCheck
+	if results == nil || juror >= len(results.Jurors) {
+		return check
+	}
+	if results.Jurors[juror].Error != "" {
+		check.Unreadable = true
+		return check
+	}
+	scores, _ := results.Scores(juror, contestant)
+	check.Feedback = len(scores.Feedback()) > 0
+
+	var assigned *Juror
+	for _, member := range comp.Jury {
+		if strings.EqualFold(strings.TrimSpace(member.Name), strings.TrimSpace(results.Jurors[juror].Juror)) {
+			assigned = member
+			break
+		}
+	}
+
+	if len(comp.Criteria) > 0 {
+		points := criterionPoints(scores, comp.Criteria)
+		for c, criterion := range comp.Criteria {
+			if assigned != nil && !assigned.scoresCriterion(criterion.Name) {
+				continue
+			}
+			check.Expected++
+			if points[c] != nil {
+				check.Entered++
+			}
+		}
+	} else {
+		for _, line := range scores.Rows {
+			for _, points := range line.Points {
+				check.Expected++
+				if points != nil {
+					check.Entered++
+				}
+			}
+		}
+	}
+
+	if comp.PointsRange != nil {
+		for _, line := range scores.Rows {
+			for i, points := range line.Points {
+				if points == nil || (*points >= comp.PointsRange.Min && *points <= comp.PointsRange.Max) {
+					continue
+				}
+				label := strings.TrimSuffix(strings.TrimSpace(line.Labels[i]), ":")
+				if label == "" {
+					label = fmt.Sprintf("#%d", i+1)
+				}
+				check.OutOfRange = append(check.OutOfRange, fmt.Sprintf("%s (%s)", label, formatScore(*points)))
+			}
+		}
+	}
+	return check
+}
+
+// Done reports whether the juror has finished scoring the contestant
+func (c ScoreCheck) Done() bool {
+	return !c.Unreadable && c.Expected > 0 && c.Entered == c.Expected && len(c.OutOfRange) == 0 && c.Feedback
+}
+
+// Text returns the short status shown in the monitor grid
+func (c ScoreCheck) Text() string {
+	switch {
+	case c.Unreadable:
+		return "unreadable"
+	case c.Entered == 0:
+		return "missing"
+	case len(c.OutOfRange) > 0:
+		return "out of range"
+	case c.Entered < c.Expected:
+		return fmt.Sprintf("%d/%d", c.Entered, c.Expected)
+	case !c.Feedback:
+		return "no feedback"
+	}
+	return "done"
+}
+
+// Details describes every problem of the check
+func (c ScoreCheck) Details() string {
+	if c.Unreadable {
+		return "The juror spreadsheet could not be read."
+	}
+	var details []string
+	if c.Entered < c.Expected {
+		details = append(details, fmt.Sprintf("%d of %d points entered", c.Entered, c.Expected))
+	}
+	if len(c.OutOfRange) > 0 {
+		details = append(details, "out of range: "+strings.Join(c.OutOfRange, ", "))
+	}
+	if !c.Feedback {
+		details = append(details, "no feedback")
+	}
+	if len(details) == 0 {
+		return "Scoring is complete."
+	}
+	return strings.Join(details, "; ")
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	log "github.com/s00500/env_logger"
+)
+
+// Scoring Monitor Window Function
+func showScoringMonitor(myApp fyne.App, comp Competition) {
+	monitorWindow := myApp.NewWindow(fmt.Sprintf("Scoring Monitor of '%s'", comp.Name))
+	monitorWindow.Resize(fyne.NewSize(900, 550))
+	manifest := comp.Generation
+
+	var resultsMutex sync.RWMutex
+	var results *CompetitionResults
+	checkAt := func(row, col int) ScoreCheck {
+		resultsMutex.RLock()
+		defer resultsMutex.RUnlock()
+		return checkScores(comp, results, col, manifest.Tabs[row].Contestant)
+	}
+
+	// Number of contestants a juror has finished
+	jurorProgress := func(col int) string {
+		done := 0
+		for row := range manifest.Tabs {
+			if checkAt(row, col).Done() {
+				done++
+			}
+		}
+		return fmt.Sprintf("%d/%d", done, len(manifest.Tabs))
+	}
+
+	detail := widget.NewLabel("Select a cell to see what is missing.")
+	detail.Wrapping = fyne.TextWrapWord
+
+	// Contestants in rows, jurors in columns
+	grid := widget.NewTable(
+		func() (int, int) {
+			return len(manifest.Tabs), len(manifest.JurorSheets)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			resultsMutex.RLock()
+			fetched := results != nil
+			resultsMutex.RUnlock()
+			if !fetched {
+				label.Importance = widget.MediumImportance
+				label.SetText("")
+				return
+			}
+			check := checkAt(id.Row, id.Col)
+			switch {
+			case check.Done():
+				label.Importance = widget.SuccessImportance
+			case check.Unreadable || len(check.OutOfRange) > 0:
+				label.Importance = widget.DangerImportance
+			default:
+				label.Importance = widget.WarningImportance
+			}
+			label.SetText(check.Text())
+		},
+	)
+	grid.ShowHeaderRow = true
+	grid.ShowHeaderColumn = true
+	grid.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	}
+	grid.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		label := obj.(*widget.Label)
+		switch {
+		case id.Row < 0 && id.Col >= 0:
+			label.SetText(fmt.Sprintf("%s (%s)", manifest.JurorSheets[id.Col].Juror, jurorProgress(id.Col)))
+		case id.Col < 0 && id.Row >= 0:
+			label.SetText(fmt.Sprintf("%d. %s", id.Row+1, manifest.Tabs[id.Row].Contestant))
+		default:
+			label.SetText("")
+		}
+	}
+	grid.SetColumnWidth(-1, 220)
+	for i := range manifest.JurorSheets {
+		grid.SetColumnWidth(i, 150)
+	}
+	grid.OnSelected = func(id widget.TableCellID) {
+		if id.Row < 0 || id.Col < 0 {
+			return
+		}
+		detail.SetText(fmt.Sprintf("%s, %s: %s", manifest.JurorSheets[id.Col].Juror, manifest.Tabs[id.Row].Contestant, checkAt(id.Row, id.Col).Details()))
+	}
+
+	statusLabel := widget.NewLabel("Reading the juror spreadsheets...")
+	updateStatus := func() {
+		resultsMutex.RLock()
+		defer resultsMutex.RUnlock()
+		if results == nil {
+			return
+		}
+		done, total := 0, len(manifest.Tabs)*len(manifest.JurorSheets)
+		for col := range manifest.JurorSheets {
+			for row := range manifest.Tabs {
+				if checkScores(comp, results, col, manifest.Tabs[row].Contestant).Done() {
+					done++
+				}
+			}
+		}
+		status := fmt.Sprintf("Last update %s - %d of %d scores complete", results.FetchedAt.Local().Format("15:04:05"), done, total)
+		if comp.PointsRange == nil {
+			status += " - no points range set, values are not range-checked"
+		}
+		statusLabel.SetText(status)
+	}
+
+	// Reads the spreadsheets now and again after every interval until the window is closed
+	interval := refreshIntervals[0].Interval
+	paused := false
+	var intervalMutex sync.Mutex
+	refresh := func(requested bool) {
+		intervalMutex.Lock()
+		skip := paused && !requested
+		intervalMutex.Unlock()
+		if skip {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		fetched, err := fetchResults(ctx, myApp.Preferences().String("credentials"), manifest, func(message string) {
+			statusLabel.SetText(strings.TrimSpace(message))
+		})
+		if err != nil {
+			log.Printf("Failed to read the juror spreadsheets: %v", err)
+			statusLabel.SetText(fmt.Sprintf("Failed to read the juror spreadsheets: %v", err))
+			return
+		}
+		resultsMutex.Lock()
+		results = fetched
+		resultsMutex.Unlock()
+		if err := cacheResults(comp.ID, fetched); err != nil {
+			log.Printf("Failed to cache results: %v", err)
+		}
+		updateStatus()
+		grid.Refresh()
+	}
+	refreshNow, stop := pollResults(func() time.Duration {
+		intervalMutex.Lock()
+		defer intervalMutex.Unlock()
+		return interval
+	}, refresh)
+	monitorWindow.SetOnClosed(stop)
+
+	intervalNames := []string{}
+	for _, option := range refreshIntervals {
+		intervalNames = append(intervalNames, option.Name)
+	}
+	intervalSelect := widget.NewSelect(intervalNames, func(name string) {
+		for _, option := range refreshIntervals {
+			if option.Name == name {
+				intervalMutex.Lock()
+				interval = option.Interval
+				intervalMutex.Unlock()
+			}
+		}
+	})
+	intervalSelect.Selected = refreshIntervals[0].Name
+
+	pauseCheck := widget.NewCheck("Pause", func(checked bool) {
+		intervalMutex.Lock()
+		paused = checked
+		intervalMutex.Unlock()
+	})
+	refreshButton := widget.NewButton("Refresh Now", refreshNow)
+
+	legend := widget.NewLabel("done, missing, n/m points entered, no feedback, out of range, unreadable spreadsheet. Juror columns show the contestants they have finished.")
+	legend.Wrapping = fyne.TextWrapWord
+	monitorWindow.SetContent(container.NewBorder(
+		container.NewBorder(nil, nil, nil,
+			container.NewHBox(widget.NewLabel("Every"), intervalSelect, pauseCheck, refreshButton),
+			statusLabel,
+		),
+		container.NewVBox(legend, widget.NewSeparator(), detail),
+		nil, nil,
+		grid,
+	))
+	monitorWindow.Show()
+}
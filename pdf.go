@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// Page geometry of the PDF documents, in points (A4)
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+	pdfMargin     = 50.0
+	pdfLineGap    = 1.35 // Line height relative to the text size
+)
+
+// pdfDocument is a minimal PDF writer for the reports. It lays out text top to bottom with the
+// standard Helvetica fonts, which every PDF reader provides, so nothing needs to be embedded
+// except images. Coordinates are measured from the top left corner of the page.
+type pdfDocument struct {
+	pages  []*bytes.Buffer
+	page   *bytes.Buffer
+	y      float64 // Top of the next line
+	images []image.Image
+	footer string // Left part of the footer on every page, next to the page number
+}
+
+// pdfColumn is a table column
+type pdfColumn struct {
+	Title string
+	Width float64
+	Right bool // Right-aligned, for numbers
+}
+
+func newPDFDocument(footer string) *pdfDocument {
+	document := &pdfDocument{footer: footer}
+	document.addPage()
+	return document
+}
+
+// addPage starts a new page and moves to its top
+func (d *pdfDocument) addPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = pdfMargin
+}
+
+// ensureSpace starts a new page unless height fits above the bottom margin
+func (d *pdfDocument) ensureSpace(height float64) {
+	if d.y+height > pdfPageHeight-pdfMargin {
+		d.addPage()
+	}
+}
+
+// text draws a single line with its baseline at y
+func (d *pdfDocument) text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, pdfPageHeight-y, pdfEscape(text))
+}
+
+// line draws a thin line
+func (d *pdfDocument) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, pdfPageHeight-y1, x2, pdfPageHeight-y2)
+}
+
+// image draws an image with its top left corner at x, y
+func (d *pdfDocument) image(img image.Image, x, y, width, height float64) {
+	d.images = append(d.images, img)
+	fmt.Fprintf(d.page, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", width, height, x, pdfPageHeight-y-height, len(d.images))
+}
+
+// paragraph writes text wrapped to the width between the margins
+func (d *pdfDocument) paragraph(text string, size float64, bold bool) {
+	for _, line := range wrapPDFText(text, size, bold, pdfPageWidth-2*pdfMargin) {
+		d.ensureSpace(size * pdfLineGap)
+		d.text(pdfMargin, d.y+size, size, bold, line)
+		d.y += size * pdfLineGap
+	}
+}
+
+// heading writes a bold heading with some space above it, keeping room for a few lines below
+func (d *pdfDocument) heading(text string, size float64) {
+	d.y += size * 0.6
+	d.ensureSpace(size*pdfLineGap + 60)
+	d.paragraph(text, size, true)
+	d.y += size * 0.3
+}
+
+// space moves down by height
+func (d *pdfDocument) space(height float64) {
+	d.y += height
+}
+
+// table writes a table with a header row. Cells are wrapped to the column width, and the
+// header is repeated on every page the table continues on.
+func (d *pdfDocument) table(columns []pdfColumn, rows [][]string, size float64) {
+	lineHeight := size * pdfLineGap
+	writeRow := func(cells []string, bold bool) {
+		wrapped := make([][]string, len(columns))
+		lines := 1
+		for c, column := range columns {
+			if c < len(cells) {
+				wrapped[c] = wrapPDFText(cells[c], size, bold, column.Width-6)
+			}
+			if len(wrapped[c]) > lines {
+				lines = len(wrapped[c])
+			}
+		}
+		x := pdfMargin
+		for c, column := range columns {
+			for l, text := range wrapped[c] {
+				textX := x
+				if column.Right {
+					textX = x + column.Width - 6 - pdfTextWidth(text, size, bold)
+				}
+				d.text(textX, d.y+size+float64(l)*lineHeight, size, bold, text)
+			}
+			x += column.Width
+		}
+		d.y += float64(lines)*lineHeight + 2
+	}
+	tableWidth := 0.0
+	for _, column := range columns {
+		tableWidth += column.Width
+	}
+	header := func() {
+		var titles []string
+		for _, column := range columns {
+			titles = append(titles, column.Title)
+		}
+		writeRow(titles, true)
+		d.line(pdfMargin, d.y, pdfMargin+tableWidth, d.y)
+		d.y += 3
+	}
+
+	d.ensureSpace(3 * lineHeight)
+	header()
+	for _, row := range rows {
+		if d.y+lineHeight > pdfPageHeight-pdfMargin {
+			d.addPage()
+			header()
+		}
+		writeRow(row, false)
+	}
+}
+
+// bytes assembles the PDF file
+func (d *pdfDocument) bytes() ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) int {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+		return len(offsets)
+	}
+	stream := func(dictionary string, data []byte) int {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n<< %s /Length %d >>\nstream\n", len(offsets), dictionary, len(data))
+		out.Write(data)
+		out.WriteString("\nendstream\nendobj\n")
+		return len(offsets)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	offsets = append(offsets, 0) // Pages, written once the page objects are known
+	pagesIndex := len(offsets) - 1
+	regular := object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	bold := object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	var imageRefs []string
+	for i, img := range d.images {
+		rgb, alpha, err := pdfImageData(img)
+		if err != nil {
+			return nil, err
+		}
+		bounds := img.Bounds()
+		mask := ""
+		if alpha != nil {
+			maskID := stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode",
+				bounds.Dx(), bounds.Dy()), alpha)
+			mask = fmt.Sprintf(" /SMask %d 0 R", maskID)
+		}
+		id := stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode%s",
+			bounds.Dx(), bounds.Dy(), mask), rgb)
+		imageRefs = append(imageRefs, fmt.Sprintf("/Im%d %d 0 R", i+1, id))
+	}
+	resources := fmt.Sprintf("<< /Font << /F1 %d 0 R /F2 %d 0 R >> /XObject << %s >> >>", regular, bold, strings.Join(imageRefs, " "))
+
+	var kids []string
+	for i, page := range d.pages {
+		content := page.Bytes()
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		var footerText bytes.Buffer
+		fmt.Fprintf(&footerText, "BT /F1 8 Tf %.2f %.2f Td (%s) Tj ET\n", pdfMargin, pdfMargin/2, pdfEscape(d.footer))
+		fmt.Fprintf(&footerText, "BT /F1 8 Tf %.2f %.2f Td (%s) Tj ET\n", pdfPageWidth-pdfMargin-pdfTextWidth(footer, 8, false), pdfMargin/2, pdfEscape(footer))
+		contentID := stream("", append(append([]byte(nil), content...), footerText.Bytes()...))
+		pageID := object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources %s /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, resources, contentID))
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageID))
+	}
+	offsets[pagesIndex] = out.Len()
+	fmt.Fprintf(&out, "2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(kids))
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes(), nil
+}
+
+// pdfImageData returns the compressed RGB samples of an image and, if it is not opaque, its
+// compressed alpha channel
+func pdfImageData(img image.Image) (rgb, alpha []byte, err error) {
+	bounds := img.Bounds()
+	var rgbData, alphaData bytes.Buffer
+	opaque := true
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			// Undo the premultiplied alpha of the color model
+			if a > 0 && a < 0xffff {
+				r, g, b = r*0xffff/a, g*0xffff/a, b*0xffff/a
+			}
+			rgbData.Write([]byte{byte(r >> 8), byte(g >> 8), byte(b >> 8)})
+			alphaData.WriteByte(byte(a >> 8))
+			opaque = opaque && a == 0xffff
+		}
+	}
+	if rgb, err = pdfCompress(rgbData.Bytes()); err != nil {
+		return nil, nil, err
+	}
+	if !opaque {
+		if alpha, err = pdfCompress(alphaData.Bytes()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return rgb, alpha, nil
+}
+
+func pdfCompress(data []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress PDF image: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress PDF image: %w", err)
+	}
+	return compressed.Bytes(), nil
+}
+
+// pdfWinAnsi maps the characters outside Latin-1 that WinAnsiEncoding provides
+var pdfWinAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b,
+	'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// pdfEncode converts text to WinAnsiEncoding, replacing characters it lacks with '?'
+func pdfEncode(text string) []byte {
+	var encoded []byte
+	for _, r := range text {
+		switch {
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		case r == '\t', r == '\n':
+			encoded = append(encoded, ' ')
+		default:
+			if b, ok := pdfWinAnsi[r]; ok {
+				encoded = append(encoded, b)
+			} else {
+				encoded = append(encoded, '?')
+			}
+		}
+	}
+	return encoded
+}
+
+// pdfEscape encodes text for a PDF string literal
+func pdfEscape(text string) string {
+	var escaped strings.Builder
+	for _, b := range pdfEncode(text) {
+		switch b {
+		case '(', ')', '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteByte(b)
+		default:
+			escaped.WriteByte(b)
+		}
+	}
+	return escaped.String()
+}
+
+// Widths of the printable ASCII characters in Helvetica and Helvetica-Bold, in 1/1000 of the
+// text size. Other characters are measured with pdfDefaultWidth.
+var (
+	pdfHelveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	pdfHelveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+const pdfDefaultWidth = 600 // Width of characters outside ASCII, slightly wider than most letters
+
+// pdfTextWidth measures text in points
+func pdfTextWidth(text string, size float64, bold bool) float64 {
+	widths := &pdfHelveticaWidths
+	if bold {
+		widths = &pdfHelveticaBoldWidths
+	}
+	total := 0
+	for _, b := range pdfEncode(text) {
+		if b >= 0x20 && b < 0x7f {
+			total += widths[b-0x20]
+		} else {
+			total += pdfDefaultWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// wrapPDFText breaks text into lines no wider than width. Words longer than a line are cut.
+func wrapPDFText(text string, size float64, bold bool, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := strings.TrimSpace(line + " " + word)
+			if pdfTextWidth(candidate, size, bold) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = word
+			for pdfTextWidth(line, size, bold) > width && len([]rune(line)) > 1 {
+				runes := []rune(line)
+				cut := len(runes) - 1
+				for cut > 1 && pdfTextWidth(string(runes[:cut]), size, bold) > width {
+					cut--
+				}
+				lines = append(lines, string(runes[:cut]))
+				line = string(runes[cut:])
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
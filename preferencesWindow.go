@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"image/color"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
 
 	log "github.com/s00500/env_logger"
 	"google.golang.org/api/drive/v3"
@@ -84,10 +86,77 @@ func showPreferences(myApp fyne.App, parent fyne.Window) {
 		}, preferencesWindow)
 	})
 
+	// Data Folder Entry
+	dataDirEntry := widget.NewEntry()
+	dataDirEntry.SetPlaceHolder("Default user data folder")
+	dataDirEntry.SetText(myApp.Preferences().StringWithFallback(dataDirPreference, ""))
+	browseButton := widget.NewButton("Browse...", func() {
+		dialog.ShowFolderOpen(func(folder fyne.ListableURI, err error) {
+			if err != nil || folder == nil {
+				if err != nil {
+					log.Printf("Folder selection error: %v", err)
+				}
+				return
+			}
+			dataDirEntry.SetText(folder.Path())
+		}, preferencesWindow)
+	})
+	defaultButton := widget.NewButton("Use Default", func() {
+		dataDirEntry.SetText("")
+	})
+	defaultDir, _ := defaultDataDir()
+	spaceAboveDataDir := canvas.NewRectangle(color.Transparent)
+	spaceAboveDataDir.SetMinSize(fyne.NewSize(0, 20)) // 20 pixels of space
+	dataDirHint := widget.NewLabel(fmt.Sprintf("Default: %s", defaultDir))
+	dataDirHint.Wrapping = fyne.TextWrapWord
+
+	// Competition Archive Entry
+	archiveEntry := widget.NewEntry()
+	archiveEntry.SetPlaceHolder("None, use the data folder")
+	archiveEntry.SetText(myApp.Preferences().StringWithFallback(archiveFilePreference, ""))
+	archiveBrowseButton := widget.NewButton("Browse...", func() {
+		dialog.ShowFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				if err != nil {
+					log.Printf("File selection error: %v", err)
+				}
+				return
+			}
+			writer.Close()
+			archiveEntry.SetText(writer.URI().Path())
+		}, preferencesWindow)
+	})
+	archiveClearButton := widget.NewButton("Clear", func() {
+		archiveEntry.SetText("")
+	})
+	archiveHint := widget.NewLabel("A single archive file holds all competitions and can be copied between computers.")
+	archiveHint.Wrapping = fyne.TextWrapWord
+
 	// Save Button
 	saveButton := widget.NewButton("Save", func() {
 		myApp.Preferences().SetString("folder_id", folderIDEntry.Text)
-		dialog.ShowInformation("Success", "Preferences saved successfully.", preferencesWindow)
+
+		newDataDir := strings.TrimSpace(dataDirEntry.Text)
+		dataDirChanged := newDataDir != myApp.Preferences().String(dataDirPreference)
+		if newDataDir != "" && !filepath.IsAbs(newDataDir) {
+			dialog.ShowError(fmt.Errorf("The data folder must be an absolute path."), preferencesWindow)
+			return
+		}
+
+		newArchive := strings.TrimSpace(archiveEntry.Text)
+		archiveChanged := newArchive != myApp.Preferences().String(archiveFilePreference)
+		if newArchive != "" && !filepath.IsAbs(newArchive) {
+			dialog.ShowError(fmt.Errorf("The competition archive must be an absolute path."), preferencesWindow)
+			return
+		}
+		myApp.Preferences().SetString(dataDirPreference, newDataDir)
+		myApp.Preferences().SetString(archiveFilePreference, newArchive)
+
+		message := "Preferences saved successfully."
+		if dataDirChanged || archiveChanged {
+			message += "\nThe new storage location is used after restarting the app."
+		}
+		dialog.ShowInformation("Success", message, parent)
 		preferencesWindow.Close()
 	})
 
@@ -100,6 +169,13 @@ func showPreferences(myApp fyne.App, parent fyne.Window) {
 		uploadButton,
 		warningContainer,
 		statusText,
+		spaceAboveDataDir,
+		widget.NewLabelWithStyle("Data folder for competitions:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		container.NewBorder(nil, nil, nil, container.NewHBox(browseButton, defaultButton), dataDirEntry),
+		dataDirHint,
+		widget.NewLabelWithStyle("Competition archive (optional):", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		container.NewBorder(nil, nil, nil, container.NewHBox(archiveBrowseButton, archiveClearButton), archiveEntry),
+		archiveHint,
 		container.NewHBox(
 			layout.NewSpacer(),
 			saveButton,
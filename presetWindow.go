@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// New From Preset Dialog Function
+func showNewFromPreset(parent fyne.Window, onCreate func(comp Competition)) {
+	presets, err := listPresets()
+	if err != nil {
+		dialog.ShowError(err, parent)
+		return
+	}
+	competitions, err := store.List()
+	if err != nil {
+		dialog.ShowError(err, parent)
+		return
+	}
+	if len(presets) == 0 && len(competitions) == 0 {
+		dialog.ShowInformation("New from Preset", "There are no presets or competitions to start from yet.", parent)
+		return
+	}
+
+	// Presets are listed before the previous competitions. Names are made unique the same
+	// way as in the competition selector, so entries sharing a name stay apart.
+	type source struct {
+		id       string
+		name     string
+		isPreset bool
+	}
+	sources := make(map[string]source)
+	var options []string
+	addSources := func(prefix string, infos []CompetitionInfo, isPreset bool) {
+		names, ids := competitionOptions(infos)
+		for _, name := range names {
+			option := fmt.Sprintf("%s: %s", prefix, name)
+			sources[option] = source{id: ids[name], name: name, isPreset: isPreset}
+			options = append(options, option)
+		}
+	}
+	addSources("Preset", presets, true)
+	addSources("Competition", competitions, false)
+
+	var setup *Competition
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Name of the new competition")
+	summary := widget.NewLabel("")
+	summary.Wrapping = fyne.TextWrapWord
+	var deleteButton *widget.Button
+
+	sourceSelect := widget.NewSelect(options, func(selected string) {
+		src := sources[selected]
+		var comp Competition
+		var err error
+		if src.isPreset {
+			comp, err = loadPreset(src.id)
+		} else {
+			comp, err = store.Load(src.id)
+			comp = competitionSetup(comp)
+		}
+		if err != nil {
+			setup = nil
+			summary.SetText(fmt.Sprintf("Failed to read: %v", err))
+			return
+		}
+		setup = &comp
+		nameEntry.SetText(comp.Name)
+		summary.SetText(fmt.Sprintf("Copies %d juror(s), %d criteria, the template and the schedule settings. Contestants, event dates and generated spreadsheets are not copied.",
+			len(comp.Jury), len(comp.Criteria)))
+		if src.isPreset {
+			deleteButton.Enable()
+		} else {
+			deleteButton.Disable()
+		}
+	})
+	sourceSelect.PlaceHolder = "Select a preset or competition"
+
+	deleteButton = widget.NewButton("Delete Preset", func() {
+		src := sources[sourceSelect.Selected]
+		if !src.isPreset {
+			return
+		}
+		dialog.ShowConfirm("Delete Preset", fmt.Sprintf("Delete '%s'?", src.name), func(confirm bool) {
+			if !confirm {
+				return
+			}
+			if err := deletePreset(src.id); err != nil {
+				dialog.ShowError(err, parent)
+				return
+			}
+			remaining := []string{}
+			for _, option := range sourceSelect.Options {
+				if option != sourceSelect.Selected {
+					remaining = append(remaining, option)
+				}
+			}
+			sourceSelect.Options = remaining
+			sourceSelect.ClearSelected()
+			setup = nil
+			summary.SetText("")
+			deleteButton.Disable()
+		}, parent)
+	})
+	deleteButton.Disable()
+
+	content := container.NewVBox(
+		widget.NewLabel("Start from:"),
+		container.NewBorder(nil, nil, nil, deleteButton, sourceSelect),
+		summary,
+		widget.NewLabel("Name:"),
+		nameEntry,
+	)
+	newDialog := dialog.NewCustomConfirm("New from Preset", "Create", "Cancel", content, func(confirm bool) {
+		if !confirm || setup == nil {
+			return
+		}
+		comp := *setup
+		comp.Name = strings.TrimSpace(nameEntry.Text)
+		onCreate(comp)
+	}, parent)
+	newDialog.Resize(fyne.NewSize(500, 320))
+	newDialog.Show()
+}
+
+// Save Preset Dialog Function
+func showSavePreset(parent fyne.Window, comp Competition) {
+	showNameDialog("Save as Preset", "Save", comp.Name, parent, func(name string) {
+		save := func() {
+			if err := savePreset(name, comp); err != nil {
+				dialog.ShowError(err, parent)
+				return
+			}
+			dialog.ShowInformation("Preset Saved", fmt.Sprintf("The preset '%s' can now be used with New from Preset.", strings.TrimSpace(name)), parent)
+		}
+		if !presetExists(name) {
+			save()
+			return
+		}
+		dialog.ShowConfirm("Replace Preset", fmt.Sprintf("A preset named '%s' already exists. Replace it?", strings.TrimSpace(name)), func(confirm bool) {
+			if confirm {
+				save()
+			}
+		}, parent)
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const presetsDir = "presets" // Store folder holding competition presets, next to the competitions
+
+// competitionSetup returns the part of a competition that carries over to the next edition:
+// jury, template, weights, criteria, points range, aggregation, tie-break rules, schedule
+// settings and the event details that rarely change. Contestants, draws, dates and the
+// generation state are cleared.
+func competitionSetup(comp Competition) Competition {
+	setup := Competition{
+		Name:          comp.Name,
+		Event:         comp.Event,
+		SourceSheetID: comp.SourceSheetID,
+		Schedule:      comp.Schedule,
+		Aggregation:   comp.Aggregation,
+		TrimCount:     comp.TrimCount,
+	}
+	setup.Event.StartDate = ""
+	setup.Event.EndDate = ""
+	setup.Schedule.Saunas = append([]string(nil), comp.Schedule.Saunas...)
+
+	setup.Jury = make([]*Juror, 0, len(comp.Jury))
+	for _, juror := range comp.Jury {
+		copied := *juror
+		copied.Criteria = append([]string(nil), juror.Criteria...)
+		setup.Jury = append(setup.Jury, &copied)
+	}
+	for _, criterion := range comp.Criteria {
+		copied := *criterion
+		setup.Criteria = append(setup.Criteria, &copied)
+	}
+	if comp.PointsRange != nil {
+		pointsRange := *comp.PointsRange
+		setup.PointsRange = &pointsRange
+	}
+	setup.TieBreaks = append([]TieBreakRule(nil), comp.TieBreaks...)
+	setup.Contestants = []*Contestant{}
+	return setup
+}
+
+// listPresets returns the stored presets sorted by name
+func listPresets() ([]CompetitionInfo, error) {
+	documents, err := store.ListDocuments(presetsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read presets: %w", err)
+	}
+
+	var infos []CompetitionInfo
+	for _, document := range documents {
+		infos = append(infos, CompetitionInfo{ID: document.ID, Name: competitionName(document.Data, document.ID), Modified: document.Modified})
+	}
+	sortCompetitionInfos(infos)
+	return infos, nil
+}
+
+// loadPreset reads a preset
+func loadPreset(id string) (Competition, error) {
+	data, err := store.ReadDocument(presetsDir, id)
+	if err != nil {
+		return Competition{}, fmt.Errorf("failed to read preset: %w", err)
+	}
+	if data == nil {
+		return Competition{}, fmt.Errorf("preset '%s' not found", id)
+	}
+	preset, _, err := parseCompetition(data)
+	if err != nil {
+		return Competition{}, err
+	}
+	return competitionSetup(preset), nil
+}
+
+// savePreset stores the setup of a competition as a preset named name. A preset with the
+// same name is replaced.
+func savePreset(name string, comp Competition) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("Preset name cannot be empty.")
+	}
+	infos, err := listPresets()
+	if err != nil {
+		return err
+	}
+	id := newCompetitionID()
+	for _, info := range infos {
+		if normalizeRosterName(info.Name) == normalizeRosterName(name) {
+			id = info.ID
+			break
+		}
+	}
+
+	preset := competitionSetup(comp)
+	preset.Name = name
+	data, err := encodeCompetition(preset)
+	if err != nil {
+		return err
+	}
+	if err := store.WriteDocument(presetsDir, id, data); err != nil {
+		return fmt.Errorf("failed to save preset: %w", err)
+	}
+	return nil
+}
+
+// deletePreset removes a preset
+func deletePreset(id string) error {
+	if err := store.DeleteDocument(presetsDir, id); err != nil {
+		return fmt.Errorf("failed to delete preset: %w", err)
+	}
+	return nil
+}
+
+// presetExists reports whether a preset with the given name is stored
+func presetExists(name string) bool {
+	infos, err := listPresets()
+	if err != nil {
+		return false
+	}
+	for _, info := range infos {
+		if normalizeRosterName(info.Name) == normalizeRosterName(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Scoring Rules Window Function
+func showRankingRules(myApp fyne.App, history *UndoHistory, current *Competition, jurors *[]*Juror) {
+	rankingWindow := myApp.NewWindow("Scoring Rules")
+	rankingWindow.Resize(fyne.NewSize(650, 450))
+
+	// The edits are undone together
+	type rulesState struct {
+		PointsRange *PointsRange
+		Aggregation string
+		TrimCount   int
+		TieBreaks   []TieBreakRule
+	}
+	recordWindowEdits(history, rankingWindow, "Edit Scoring Rules", func() rulesState {
+		state := rulesState{Aggregation: current.Aggregation, TrimCount: current.TrimCount, TieBreaks: append([]TieBreakRule(nil), current.TieBreaks...)}
+		if current.PointsRange != nil {
+			pointsRange := *current.PointsRange
+			state.PointsRange = &pointsRange
+		}
+		return state
+	}, func(state rulesState) {
+		current.Aggregation, current.TrimCount = state.Aggregation, state.TrimCount
+		current.TieBreaks = append([]TieBreakRule(nil), state.TieBreaks...)
+		current.PointsRange = nil
+		if state.PointsRange != nil {
+			pointsRange := *state.PointsRange
+			current.PointsRange = &pointsRange
+		}
+	})
+
+	kindNames := []string{}
+	kindByName := make(map[string]string)
+	for _, kind := range tieBreakKinds {
+		kindNames = append(kindNames, kind.Name)
+		kindByName[kind.Name] = kind.Kind
+	}
+
+	// Aggregation of the juror scores
+	modeNames := []string{}
+	modeByName := make(map[string]string)
+	for _, mode := range aggregationModes {
+		modeNames = append(modeNames, mode.Name)
+		modeByName[mode.Name] = mode.Mode
+	}
+	trimEntry := widget.NewEntry()
+	trimEntry.SetText(strconv.Itoa(trimCount(*current)))
+	trimEntry.OnChanged = func(text string) {
+		if count, err := strconv.Atoi(strings.TrimSpace(text)); err == nil && count > 0 {
+			current.TrimCount = count
+		}
+	}
+	trimRow := container.NewBorder(nil, nil, widget.NewLabel("Scores dropped at each end:"), nil, trimEntry)
+	modeSelect := widget.NewSelect(modeNames, func(name string) {
+		current.Aggregation = modeByName[name]
+		if current.Aggregation == aggregationWeightedMean {
+			current.Aggregation = ""
+		}
+		if current.Aggregation == aggregationTrimmedMean {
+			trimRow.Show()
+		} else {
+			trimRow.Hide()
+		}
+	})
+	modeSelect.SetSelected(aggregationName(aggregationMode(*current)))
+	modeHint := widget.NewLabel("The trimmed mean, the median and the sum of ranks count every juror equally; jurors weighted with 0% are left out. The sum of ranks adds up each juror's placing of the contestant, and the lowest sum wins.")
+	modeHint.Wrapping = fyne.TextWrapWord
+
+	// Points range checked by the scoring monitor; both fields empty for none
+	formatBound := func(bound func(*PointsRange) float64) string {
+		if current.PointsRange == nil {
+			return ""
+		}
+		return formatScore(bound(current.PointsRange))
+	}
+	minEntry := widget.NewEntry()
+	minEntry.SetPlaceHolder("Min")
+	minEntry.SetText(formatBound(func(r *PointsRange) float64 { return r.Min }))
+	maxEntry := widget.NewEntry()
+	maxEntry.SetPlaceHolder("Max")
+	maxEntry.SetText(formatBound(func(r *PointsRange) float64 { return r.Max }))
+	// An incomplete or invalid range is cleared rather than keeping the previous one unseen
+	rangeError := widget.NewLabel("")
+	rangeError.Importance = widget.DangerImportance
+	rangeError.Wrapping = fyne.TextWrapWord
+	rangeError.Hide()
+	updateRange := func(string) {
+		pointsRange, err := parsePointsRange(minEntry.Text, maxEntry.Text)
+		current.PointsRange = pointsRange
+		if err != nil {
+			rangeError.SetText(fmt.Sprintf("%v The points are not checked until the range is valid.", err))
+			rangeError.Show()
+		} else {
+			rangeError.Hide()
+		}
+	}
+	minEntry.OnChanged = updateRange
+	maxEntry.OnChanged = updateRange
+
+	ruleRows := container.NewVBox()
+	var rebuildRules func()
+	rebuildRules = func() {
+		criterionNames := []string{}
+		for _, criterion := range current.Criteria {
+			if name := strings.TrimSpace(criterion.Name); name != "" {
+				criterionNames = append(criterionNames, name)
+			}
+		}
+		jurorNames := []string{}
+		jurorsMutex.RLock()
+		for _, juror := range *jurors {
+			if name := strings.TrimSpace(juror.Name); name != "" {
+				jurorNames = append(jurorNames, name)
+			}
+		}
+		jurorsMutex.RUnlock()
+
+		ruleRows.RemoveAll()
+		if len(current.TieBreaks) == 0 {
+			ruleRows.Add(widget.NewLabel("No tie-break rules. Contestants with the same total share the placing."))
+		}
+		for i := range current.TieBreaks {
+			i := i
+			rule := &current.TieBreaks[i]
+
+			// The second select names the criterion or juror the rule compares
+			parameterSelect := widget.NewSelect(nil, nil)
+			updateParameter := func() {
+				switch rule.Kind {
+				case tieBreakCriterion:
+					parameterSelect.Options = criterionNames
+					parameterSelect.PlaceHolder = "Select a criterion"
+					parameterSelect.Selected = rule.Criterion
+					parameterSelect.OnChanged = func(name string) { rule.Criterion = name }
+					parameterSelect.Show()
+				case tieBreakHeadJuror:
+					parameterSelect.Options = jurorNames
+					parameterSelect.PlaceHolder = "Select the head juror"
+					parameterSelect.Selected = rule.Juror
+					parameterSelect.OnChanged = func(name string) { rule.Juror = name }
+					parameterSelect.Show()
+				default:
+					parameterSelect.Hide()
+				}
+				parameterSelect.Refresh()
+			}
+
+			kindSelect := widget.NewSelect(kindNames, nil)
+			for _, kind := range tieBreakKinds {
+				if kind.Kind == rule.Kind {
+					kindSelect.Selected = kind.Name
+				}
+			}
+			kindSelect.OnChanged = func(name string) {
+				rule.Kind = kindByName[name]
+				rule.Criterion, rule.Juror = "", ""
+				updateParameter()
+			}
+			updateParameter()
+
+			upButton := widget.NewButton("Up", func() {
+				current.TieBreaks[i-1], current.TieBreaks[i] = current.TieBreaks[i], current.TieBreaks[i-1]
+				rebuildRules()
+			})
+			if i == 0 {
+				upButton.Disable()
+			}
+			removeButton := widget.NewButton("Remove", func() {
+				current.TieBreaks = append(current.TieBreaks[:i], current.TieBreaks[i+1:]...)
+				rebuildRules()
+			})
+
+			ruleRows.Add(container.NewBorder(nil, nil,
+				widget.NewLabel(fmt.Sprintf("%d.", i+1)),
+				container.NewHBox(upButton, removeButton),
+				container.NewGridWithColumns(2, kindSelect, parameterSelect),
+			))
+		}
+		ruleRows.Refresh()
+	}
+
+	addButton := widget.NewButton("Add", func() {
+		current.TieBreaks = append(current.TieBreaks, TieBreakRule{Kind: tieBreakCountBack})
+		rebuildRules()
+	})
+	rebuildRules()
+
+	hint := widget.NewLabel("Rules are applied in order to contestants with the same total until the tie is broken. Contestants that no rule separates share the placing.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	rankingWindow.SetContent(container.NewBorder(
+		nil,
+		container.NewHBox(
+			layout.NewSpacer(),
+			widget.NewButton("Close", func() {
+				rankingWindow.Close()
+			}),
+		),
+		nil, nil,
+		container.NewVScroll(container.NewVBox(
+			container.NewBorder(nil, nil,
+				widget.NewLabelWithStyle("Points per criterion:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				nil,
+				container.NewGridWithColumns(2, minEntry, maxEntry),
+			),
+			rangeError,
+			container.NewBorder(nil, nil,
+				widget.NewLabelWithStyle("Aggregation:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				nil,
+				modeSelect,
+			),
+			trimRow,
+			modeHint,
+			widget.NewSeparator(),
+			container.NewHBox(
+				widget.NewLabelWithStyle("Tie-break rules:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				layout.NewSpacer(),
+				addButton,
+				widget.NewButton("Reload Jurors", func() {
+					rebuildRules()
+				}),
+			),
+			ruleRows,
+			hint,
+		)),
+	))
+	rankingWindow.Show()
+}
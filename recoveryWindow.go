@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	log "github.com/s00500/env_logger"
+)
+
+// Draft Recovery Function
+func offerDraftRecovery(parent fyne.Window, onRecover func(draft Draft)) {
+	drafts, err := recoverableDrafts()
+	if err != nil {
+		log.Printf("Failed to read drafts: %v", err)
+		return
+	}
+	if len(drafts) == 0 {
+		return
+	}
+
+	var recoveryDialog dialog.Dialog
+	rows := container.NewVBox()
+	for _, draft := range drafts {
+		draft := draft
+		name := draft.Competition.Name
+		if name == "" {
+			name = "Unnamed competition"
+		}
+		status := "never saved"
+		if draft.Competition.ID != "" {
+			status = "changes not saved"
+		}
+
+		var row *fyne.Container
+		row = container.NewHBox(
+			widget.NewLabel(fmt.Sprintf("%s (%s, autosaved %s)", name, status, draft.Saved.Format("2006-01-02 15:04"))),
+			layout.NewSpacer(),
+			widget.NewButton("Discard", func() {
+				if err := removeDraft(draft.Key); err != nil {
+					dialog.ShowError(err, parent)
+					return
+				}
+				rows.Remove(row)
+				if len(rows.Objects) == 0 {
+					recoveryDialog.Hide()
+				}
+			}),
+			widget.NewButton("Recover", func() {
+				recoveryDialog.Hide()
+				onRecover(draft)
+			}),
+		)
+		rows.Add(row)
+	}
+
+	message := widget.NewLabel("The app was closed before these changes were saved. Recover a draft to continue editing it; it is not saved until you click Save.")
+	message.Wrapping = fyne.TextWrapWord
+	recoveryDialog = dialog.NewCustom("Recover Unsaved Changes", "Later", container.NewBorder(message, nil, nil, nil, container.NewVScroll(rows)), parent)
+	recoveryDialog.Resize(fyne.NewSize(600, 300))
+	recoveryDialog.Show()
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"strings"
+	"time"
+)
+
+// pdfHeader writes the logo, the competition name and the event details at the top of a report
+func pdfHeader(document *pdfDocument, comp Competition, title string) error {
+	logo, _, err := image.Decode(bytes.NewReader(logoPNG))
+	if err != nil {
+		return fmt.Errorf("failed to decode logo: %w", err)
+	}
+	width := pdfPageWidth - 2*pdfMargin
+	height := width * float64(logo.Bounds().Dy()) / float64(logo.Bounds().Dx())
+	document.image(logo, pdfMargin, document.y, width, height)
+	document.space(height + 16)
+
+	document.paragraph(comp.Name, 18, true)
+	for _, line := range comp.Event.headerLines() {
+		document.paragraph(line, 10, false)
+	}
+	document.space(6)
+	document.paragraph(title, 14, true)
+	return nil
+}
+
+// resultsReportPDF renders the official results: the ranking with the deciding tie-break
+// rules, the scores per criterion or juror, the jury with its weights and a signature block
+func resultsReportPDF(comp Competition, standings *Standings, results *CompetitionResults) ([]byte, error) {
+	document := newPDFDocument(fmt.Sprintf("%s - Results", comp.Name))
+	if err := pdfHeader(document, comp, "Official Results"); err != nil {
+		return nil, err
+	}
+	document.paragraph(fmt.Sprintf("Scores read %s · Aggregation: %s", results.FetchedAt.Local().Format("2006-01-02 15:04"), standings.Aggregation), 9, false)
+	document.space(6)
+
+	// Ranking
+	document.heading("Ranking", 12)
+	rows := [][]string{}
+	incomplete := false
+	for _, result := range standings.Results {
+		total := formatScore(result.Total)
+		if !result.Complete {
+			total += " *"
+			incomplete = true
+		}
+		rows = append(rows, []string{fmt.Sprintf("%d.", result.Rank), result.Contestant, contestantTeam(comp, result.Contestant), total, result.TieBreak})
+	}
+	document.table([]pdfColumn{
+		{Title: "Rank", Width: 40, Right: true},
+		{Title: "Contestant", Width: 150},
+		{Title: "Team", Width: 110},
+		{Title: standings.totalLabel(), Width: 70, Right: true},
+		{Title: "Tie-Break", Width: 125},
+	}, rows, 10)
+	if incomplete {
+		document.paragraph("* Not every juror has scored this contestant.", 8, false)
+	}
+	if len(comp.TieBreaks) > 0 {
+		var rules []string
+		for i, rule := range comp.TieBreaks {
+			rules = append(rules, fmt.Sprintf("%d. %s", i+1, rule))
+		}
+		document.space(4)
+		document.paragraph("Tie-break rules: "+strings.Join(rules, "; "), 8, false)
+	}
+
+	// Breakdown per criterion, or per juror for competitions without criteria
+	if len(standings.Criteria) > 0 {
+		document.heading("Scores per Criterion", 12)
+		columns, rows := breakdownTable(standings, standings.Criteria, func(result *ContestantResult, i int) *float64 {
+			return &result.CriterionScores[i]
+		})
+		for i, criterion := range comp.Criteria {
+			if i+2 < len(columns) {
+				columns[i+2].Title = fmt.Sprintf("%s (%d%%)", criterion.Name, criterion.Weight)
+			}
+		}
+		document.table(columns, rows, 9)
+	} else {
+		document.heading("Scores per Juror", 12)
+		columns, rows := breakdownTable(standings, standings.Jurors, func(result *ContestantResult, i int) *float64 {
+			return result.JurorTotals[i]
+		})
+		document.table(columns, rows, 9)
+	}
+
+	// Jury
+	document.heading("Jury", 12)
+	rows = [][]string{}
+	for i, juror := range comp.Jury {
+		criteria := "All"
+		if len(juror.Criteria) > 0 && len(comp.Criteria) > 0 {
+			criteria = strings.Join(juror.Criteria, ", ")
+		}
+		rows = append(rows, []string{fmt.Sprintf("%d.", i+1), juror.Name, juror.Club, fmt.Sprintf("%d%%", juror.Weight), criteria})
+	}
+	document.table([]pdfColumn{
+		{Title: "#", Width: 30, Right: true},
+		{Title: "Juror", Width: 150},
+		{Title: "Club", Width: 130},
+		{Title: "Weight", Width: 60, Right: true},
+		{Title: "Criteria", Width: 125},
+	}, rows, 10)
+
+	pdfSignatures(document, comp.Event.Venue, []string{"Head of Jury", "Organizer"})
+	return document.bytes()
+}
+
+// breakdownTable returns the columns and rows of a table with one score column per name
+func breakdownTable(standings *Standings, names []string, score func(result *ContestantResult, i int) *float64) ([]pdfColumn, [][]string) {
+	columns := []pdfColumn{{Title: "Rank", Width: 40, Right: true}, {Title: "Contestant", Width: 150}}
+	scoreWidth := 0.0
+	if len(names) > 0 {
+		scoreWidth = (pdfPageWidth - 2*pdfMargin - 40 - 150) / float64(len(names))
+	}
+	for _, name := range names {
+		columns = append(columns, pdfColumn{Title: name, Width: scoreWidth, Right: true})
+	}
+	var rows [][]string
+	for _, result := range standings.Results {
+		row := []string{fmt.Sprintf("%d.", result.Rank), result.Contestant}
+		for i := range names {
+			value := "-"
+			if points := score(result, i); points != nil {
+				value = formatScore(*points)
+			}
+			row = append(row, value)
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows
+}
+
+// pdfSignatures writes signature lines, preceded by the place and date of the event
+func pdfSignatures(document *pdfDocument, place string, roles []string) {
+	const gap = 40.0
+	document.space(20)
+	document.ensureSpace(110)
+	document.paragraph(fmt.Sprintf("Place, date: %s", joinNonEmpty(", ", place, time.Now().Format(eventDateLayout))), 10, false)
+	document.space(45)
+	width := (pdfPageWidth - 2*pdfMargin - gap*float64(len(roles)-1)) / float64(len(roles))
+	for i, role := range roles {
+		x := pdfMargin + float64(i)*(width+gap)
+		document.line(x, document.y, x+width, document.y)
+		document.text(x, document.y+12, 9, false, role)
+	}
+	document.space(20)
+}
+
+// contestantTeam returns the team of a contestant, if any
+func contestantTeam(comp Competition, name string) string {
+	for _, contestant := range comp.Contestants {
+		if strings.EqualFold(strings.TrimSpace(contestant.Name), strings.TrimSpace(name)) {
+			return contestant.Team
+		}
+	}
+	return ""
+}
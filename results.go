@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CompetitionResults holds the scores read back from the juror spreadsheets
+type CompetitionResults struct {
+	FetchedAt time.Time     `json:"fetched_at"`
+	Jurors    []JurorScores `json:"jurors"` // In jury order
+}
+
+// JurorScores holds everything one juror entered
+type JurorScores struct {
+	Juror         string             `json:"juror"`
+	SpreadsheetID string             `json:"spreadsheet_id"`
+	Contestants   []ContestantScores `json:"contestants"`     // In running order
+	Error         string             `json:"error,omitempty"` // Why the spreadsheet could not be read
+}
+
+// ContestantScores holds the scores a juror gave one contestant
+type ContestantScores struct {
+	Contestant string      `json:"contestant"`
+	Sheet      string      `json:"sheet"`
+	Rows       []ScoreLine `json:"rows"` // One per "Points:" row of the template
+}
+
+// ScoreLine is one "Points:" row: points per criterion, the total and the feedback
+type ScoreLine struct {
+	Labels   []string   `json:"labels"` // Criterion names from the row above the points
+	Points   []*float64 `json:"points"` // nil where nothing was entered
+	Total    *float64   `json:"total,omitempty"`
+	Feedback string     `json:"feedback,omitempty"`
+}
+
+// Total returns the sum of the row totals, using the sum of the points where a row has no
+// total. ok is false if the juror entered nothing for the contestant.
+func (c ContestantScores) Total() (total float64, ok bool) {
+	for _, line := range c.Rows {
+		if line.Total != nil {
+			total += *line.Total
+			ok = true
+			continue
+		}
+		for _, points := range line.Points {
+			if points != nil {
+				total += *points
+				ok = true
+			}
+		}
+	}
+	return total, ok
+}
+
+// Complete reports whether every criterion of every row was scored
+func (c ContestantScores) Complete() bool {
+	if len(c.Rows) == 0 {
+		return false
+	}
+	for _, line := range c.Rows {
+		for _, points := range line.Points {
+			if points == nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Feedback returns the non-empty feedback of all rows
+func (c ContestantScores) Feedback() []string {
+	var feedback []string
+	for _, line := range c.Rows {
+		if text := strings.TrimSpace(line.Feedback); text != "" {
+			feedback = append(feedback, text)
+		}
+	}
+	return feedback
+}
+
+// Scores returns the scores a juror gave a contestant, if the tab was read
+func (r *CompetitionResults) Scores(juror int, contestant string) (ContestantScores, bool) {
+	if r == nil || juror < 0 || juror >= len(r.Jurors) {
+		return ContestantScores{}, false
+	}
+	for _, scores := range r.Jurors[juror].Contestants {
+		if scores.Contestant == contestant {
+			return scores, true
+		}
+	}
+	return ContestantScores{}, false
+}
+
+// fetchResults reads the scores of every juror spreadsheet listed in the manifest. A juror
+// spreadsheet that cannot be read is reported in its JurorScores, so the others are kept.
+func fetchResults(ctx context.Context, credentials string, manifest *GenerationManifest, logStatus func(message string)) (*CompetitionResults, error) {
+	if manifest.SpreadsheetCount() == 0 {
+		return nil, fmt.Errorf("The competition has not been generated yet.")
+	}
+	services, err := initializeGoogleServices(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &CompetitionResults{FetchedAt: time.Now().UTC()}
+	for i, sheet := range manifest.JurorSheets {
+		if err := checkContext(ctx); err != nil {
+			return nil, err
+		}
+		logStatus(fmt.Sprintf("Reading scores of Juror #%d (%s)...\n", i+1, sheet.Juror))
+		scores := JurorScores{Juror: sheet.Juror, SpreadsheetID: sheet.SpreadsheetID}
+		tabs, err := readContestantTabs(ctx, services.Sheets, sheet.SpreadsheetID, manifest.Tabs, lastPointsRow(manifest.PointsRows))
+		if err != nil {
+			scores.Error = err.Error()
+			logStatus(fmt.Sprintf("Error: %v\n", err))
+		} else {
+			for t, tab := range manifest.Tabs {
+				scores.Contestants = append(scores.Contestants, ContestantScores{
+					Contestant: tab.Contestant,
+					Sheet:      tab.Sheet,
+					Rows:       parseScoreLines(tabs[t], manifest.PointsRows),
+				})
+			}
+		}
+		results.Jurors = append(results.Jurors, scores)
+	}
+	logStatus("Finished reading scores.\n")
+	return results, nil
+}
+
+// Refresh intervals offered by the windows that poll the juror spreadsheets, in menu order
+var refreshIntervals = []struct {
+	Name     string
+	Interval time.Duration
+}{
+	{"30 seconds", 30 * time.Second},
+	{"1 minute", time.Minute},
+	{"2 minutes", 2 * time.Minute},
+	{"5 minutes", 5 * time.Minute},
+}
+
+// pollResults calls refresh right away and again after every interval until stop is called.
+// Timed refreshes pass false, refreshes requested with refreshNow pass true.
+func pollResults(interval func() time.Duration, refresh func(requested bool)) (refreshNow func(), stop func()) {
+	requests := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		refresh(true)
+		for {
+			select {
+			case <-done:
+				return
+			case <-requests:
+				refresh(true)
+			case <-time.After(interval()):
+				refresh(false)
+			}
+		}
+	}()
+
+	var once sync.Once
+	refreshNow = func() {
+		select {
+		case requests <- struct{}{}:
+		default:
+		}
+	}
+	stop = func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+	return refreshNow, stop
+}
+
+// parseScoreLines extracts the "Points:" rows of a contestant tab. The points run from
+// column B to the end column, followed by "Total:", the total and, one column further
+// right, the feedback, as laid out by the template.
+func parseScoreLines(values [][]interface{}, pointsRows []RowColumnInfo) []ScoreLine {
+	cell := func(row, col int) interface{} {
+		if row < 0 || row >= len(values) || col < 0 || col >= len(values[row]) {
+			return nil
+		}
+		return values[row][col]
+	}
+
+	lines := []ScoreLine{}
+	for _, info := range pointsRows {
+		row := info.Row - 1
+		end := columnLetterToIndex(info.EndColumn) // 1-based, so also the 0-based column after it
+		line := ScoreLine{}
+		for col := 1; col < end; col++ {
+			line.Labels = append(line.Labels, cellText(cell(row-1, col)))
+			line.Points = append(line.Points, cellNumber(cell(row, col)))
+		}
+		line.Total = cellNumber(cell(row, end+1))
+		line.Feedback = cellText(cell(row, end+3))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// lastPointsRow returns the 1-based row of the last "Points:" row
+func lastPointsRow(pointsRows []RowColumnInfo) int {
+	lastRow := 1
+	for _, info := range pointsRows {
+		if info.Row > lastRow {
+			lastRow = info.Row
+		}
+	}
+	return lastRow
+}
+
+// cellNumber returns the numeric value of a cell, or nil if it is empty or not a number
+func cellNumber(value interface{}) *float64 {
+	switch v := value.(type) {
+	case float64:
+		return &v
+	case string:
+		text := strings.ReplaceAll(strings.TrimSpace(v), ",", ".")
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return &number
+		}
+	}
+	return nil
+}
+
+// cellText returns the text of a cell
+func cellText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+// loadCachedResults returns the results last read for a competition, or nil if there are none
+func loadCachedResults(id string) (*CompetitionResults, error) {
+	data, err := readResultsCache(id)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var results CompetitionResults
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("the cached results are damaged: %w", err)
+	}
+	return &results, nil
+}
+
+// cacheResults stores the results read for a competition
+func cacheResults(id string, results *CompetitionResults) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize results: %w", err)
+	}
+	return writeResultsCache(id, data)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const resultsCacheDir = "results" // Store folder holding the last results read for each competition
+
+// readResultsCache returns the cached results of a competition, or nil if there are none
+func readResultsCache(id string) (json.RawMessage, error) {
+	data, err := store.ReadDocument(resultsCacheDir, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached results: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("the cached results are damaged")
+	}
+	return data, nil
+}
+
+// writeResultsCache stores the results of a competition
+func writeResultsCache(id string, data json.RawMessage) error {
+	if err := store.WriteDocument(resultsCacheDir, id, data); err != nil {
+		return fmt.Errorf("failed to cache results: %w", err)
+	}
+	return nil
+}
+
+// removeResultsCache deletes the cached results of a competition, if there are any
+func removeResultsCache(id string) error {
+	if err := store.DeleteDocument(resultsCacheDir, id); err != nil {
+		return fmt.Errorf("failed to remove cached results: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	log "github.com/s00500/env_logger"
+)
+
+// Results Window Function
+func showResultsWindow(myApp fyne.App, comp Competition) {
+	resultsWindow := myApp.NewWindow(fmt.Sprintf("Results of '%s'", comp.Name))
+	resultsWindow.Resize(fyne.NewSize(900, 550))
+	manifest := comp.Generation
+
+	results, err := loadCachedResults(comp.ID)
+	if err != nil {
+		log.Printf("Failed to read cached results: %v", err)
+	}
+
+	statusLabel := widget.NewLabel("")
+	updateStatus := func() {
+		if results == nil {
+			statusLabel.SetText("No results have been read yet. Click Refresh to read the juror spreadsheets.")
+			return
+		}
+		status := fmt.Sprintf("Read %s", results.FetchedAt.Local().Format("2006-01-02 15:04:05"))
+		for _, juror := range results.Jurors {
+			if juror.Error != "" {
+				status += fmt.Sprintf(" - the spreadsheet of %s could not be read", juror.Juror)
+			}
+		}
+		statusLabel.SetText(status)
+	}
+
+	// Ranking computed locally from the scores
+	var standings *Standings
+	warnings := widget.NewLabel("")
+	warnings.Wrapping = fyne.TextWrapWord
+	updateWarnings := func() {
+		if standings == nil {
+			warnings.SetText("")
+			return
+		}
+		text := fmt.Sprintf("Aggregation: %s", standings.Aggregation)
+		if len(standings.Warnings) > 0 {
+			text += "\nWarnings:\n" + strings.Join(standings.Warnings, "\n")
+		}
+		warnings.SetText(text)
+	}
+	rankingColumns := []string{"Rank", "Contestant", "Total", "Overview", "Status", "Tie-Break"}
+	ranking := widget.NewTable(
+		func() (int, int) {
+			if standings == nil {
+				return 0, len(rankingColumns)
+			}
+			return len(standings.Results), len(rankingColumns)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(rankingCellText(standings.Results[id.Row], id.Col))
+		},
+	)
+	ranking.ShowHeaderRow = true
+	ranking.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	}
+	ranking.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		switch {
+		case id.Col == 2 && standings != nil:
+			obj.(*widget.Label).SetText(standings.totalLabel())
+		case id.Col >= 0:
+			obj.(*widget.Label).SetText(rankingColumns[id.Col])
+		}
+	}
+	for col, width := range []float32{60, 220, 80, 80, 180, 220} {
+		ranking.SetColumnWidth(col, width)
+	}
+	updateStandings := func() {
+		standings = nil
+		if results != nil {
+			standings = computeStandings(comp, results)
+		}
+		updateWarnings()
+		ranking.Refresh()
+	}
+
+	detail := widget.NewLabel("Select a score to see the points per criterion and the feedback.")
+	detail.Wrapping = fyne.TextWrapWord
+
+	// Contestants in rows, jurors in columns
+	matrix := widget.NewTable(
+		func() (int, int) {
+			return len(manifest.Tabs), len(manifest.JurorSheets)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(scoreCellText(results, id.Col, manifest.Tabs[id.Row].Contestant))
+		},
+	)
+	matrix.ShowHeaderRow = true
+	matrix.ShowHeaderColumn = true
+	matrix.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	}
+	matrix.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		label := obj.(*widget.Label)
+		switch {
+		case id.Row < 0 && id.Col >= 0:
+			label.SetText(manifest.JurorSheets[id.Col].Juror)
+		case id.Col < 0 && id.Row >= 0:
+			label.SetText(fmt.Sprintf("%d. %s", id.Row+1, manifest.Tabs[id.Row].Contestant))
+		default:
+			label.SetText("")
+		}
+	}
+	matrix.SetColumnWidth(-1, 220)
+	for i := range manifest.JurorSheets {
+		matrix.SetColumnWidth(i, 120)
+	}
+	matrix.OnSelected = func(id widget.TableCellID) {
+		if id.Row < 0 || id.Col < 0 {
+			return
+		}
+		detail.SetText(scoreDetailText(results, id.Col, manifest.JurorSheets[id.Col].Juror, manifest.Tabs[id.Row].Contestant))
+	}
+
+	var refreshButton *widget.Button
+	refreshButton = widget.NewButton("Refresh", func() {
+		refreshButton.Disable()
+		statusLabel.SetText("Reading the juror spreadsheets...")
+		go func() {
+			defer refreshButton.Enable()
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			fetched, err := fetchResults(ctx, myApp.Preferences().String("credentials"), manifest, func(message string) {
+				statusLabel.SetText(strings.TrimSpace(message))
+			})
+			if err != nil {
+				updateStatus()
+				dialog.ShowError(fmt.Errorf("Failed to read the results: %w", err), resultsWindow)
+				return
+			}
+			results = fetched
+			if err := cacheResults(comp.ID, results); err != nil {
+				log.Printf("Failed to cache results: %v", err)
+			}
+			updateStatus()
+			updateStandings()
+			matrix.Refresh()
+		}()
+	})
+	updateStatus()
+
+	var checkButton *widget.Button
+	checkButton = widget.NewButton("Check Overview", func() {
+		if standings == nil {
+			dialog.ShowInformation("Check Overview", "Read the results first.", resultsWindow)
+			return
+		}
+		checkButton.Disable()
+		statusLabel.SetText("Reading the overview spreadsheet...")
+		go func() {
+			defer checkButton.Enable()
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			checked := computeStandings(comp, results)
+			err := crossCheckOverview(ctx, myApp.Preferences().String("credentials"), manifest, results, checked)
+			updateStatus()
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("Failed to read the overview: %w", err), resultsWindow)
+				return
+			}
+			standings = checked
+			updateWarnings()
+			ranking.Refresh()
+		}()
+	})
+	updateStandings()
+
+	exportButton := widget.NewButton("Export CSV...", func() {
+		if standings == nil {
+			dialog.ShowInformation("Export CSV", "Read the results first.", resultsWindow)
+			return
+		}
+		data, err := standingsCSV(standings)
+		if err != nil {
+			dialog.ShowError(err, resultsWindow)
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				if err != nil {
+					log.Printf("File selection error: %v", err)
+				}
+				return
+			}
+			defer writer.Close()
+			if _, err := writer.Write(data); err != nil {
+				dialog.ShowError(fmt.Errorf("Failed to write the ranking: %w", err), resultsWindow)
+			}
+		}, resultsWindow)
+		saveDialog.SetFileName(sanitizeFileName(comp.Name) + " - Ranking.csv")
+		saveDialog.Show()
+	})
+
+	pdfButton := widget.NewButton("Export PDF...", func() {
+		if standings == nil {
+			dialog.ShowInformation("Export PDF", "Read the results first.", resultsWindow)
+			return
+		}
+		data, err := resultsReportPDF(comp, standings, results)
+		if err != nil {
+			dialog.ShowError(err, resultsWindow)
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil || writer == nil {
+				if err != nil {
+					log.Printf("File selection error: %v", err)
+				}
+				return
+			}
+			defer writer.Close()
+			if _, err := writer.Write(data); err != nil {
+				dialog.ShowError(fmt.Errorf("Failed to write the report: %w", err), resultsWindow)
+			}
+		}, resultsWindow)
+		saveDialog.SetFileName(sanitizeFileName(comp.Name) + " - Results.pdf")
+		saveDialog.Show()
+	})
+
+	bookletsButton := widget.NewButton("Feedback Booklets...", func() {
+		if standings == nil {
+			dialog.ShowInformation("Feedback Booklets", "Read the results first.", resultsWindow)
+			return
+		}
+		showFeedbackExport(resultsWindow, comp, results, standings)
+	})
+
+	analysisButton := widget.NewButton("Analysis...", func() {
+		if standings == nil {
+			dialog.ShowInformation("Jury Consistency", "Read the results first.", resultsWindow)
+			return
+		}
+		showConsistencyAnalysis(myApp, comp, standings)
+	})
+
+	legend := widget.NewLabel("* incomplete, - not scored, ! spreadsheet could not be read")
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Scores", container.NewBorder(nil, container.NewVBox(legend, widget.NewSeparator(), detail), nil, nil, matrix)),
+		container.NewTabItem("Ranking", container.NewBorder(nil, container.NewVScroll(warnings), nil, nil, ranking)),
+	)
+	resultsWindow.SetContent(container.NewBorder(
+		container.NewBorder(nil, nil, nil, container.NewHBox(analysisButton, bookletsButton, pdfButton, exportButton, checkButton, refreshButton), statusLabel),
+		nil, nil, nil,
+		tabs,
+	))
+	resultsWindow.Show()
+}
+
+// scoreCellText returns the matrix cell of a juror's total for a contestant
+func scoreCellText(results *CompetitionResults, juror int, contestant string) string {
+	if results == nil || juror >= len(results.Jurors) {
+		return ""
+	}
+	if results.Jurors[juror].Error != "" {
+		return "!"
+	}
+	scores, ok := results.Scores(juror, contestant)
+	if !ok {
+		return "-"
+	}
+	total, ok := scores.Total()
+	if !ok {
+		return "-"
+	}
+	text := formatScore(total)
+	if !scores.Complete() {
+		text += " *"
+	}
+	return text
+}
+
+// scoreDetailText describes the points and feedback a juror gave a contestant
+func scoreDetailText(results *CompetitionResults, juror int, jurorName, contestant string) string {
+	if results == nil || juror >= len(results.Jurors) {
+		return "No results have been read yet."
+	}
+	if err := results.Jurors[juror].Error; err != "" {
+		return fmt.Sprintf("The spreadsheet of %s could not be read: %s", jurorName, err)
+	}
+	scores, ok := results.Scores(juror, contestant)
+	if !ok {
+		return fmt.Sprintf("%s has no scores for %s.", jurorName, contestant)
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s for %s:\n", jurorName, contestant)
+	for _, line := range scores.Rows {
+		var parts []string
+		for i, points := range line.Points {
+			label := line.Labels[i]
+			if label == "" {
+				label = fmt.Sprintf("#%d", i+1)
+			}
+			value := "-"
+			if points != nil {
+				value = formatScore(*points)
+			}
+			parts = append(parts, fmt.Sprintf("%s: %s", label, value))
+		}
+		if line.Total != nil {
+			parts = append(parts, fmt.Sprintf("Total: %s", formatScore(*line.Total)))
+		}
+		builder.WriteString(strings.Join(parts, ", ") + "\n")
+		if line.Feedback != "" {
+			fmt.Fprintf(&builder, "Feedback: %s\n", line.Feedback)
+		}
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// rankingCellText returns a cell of the ranking table
+func rankingCellText(result *ContestantResult, col int) string {
+	switch col {
+	case 0:
+		return fmt.Sprintf("%d.", result.Rank)
+	case 1:
+		return result.Contestant
+	case 2:
+		return formatScore(result.Total)
+	case 3:
+		if result.Overview == nil {
+			return ""
+		}
+		return formatScore(*result.Overview)
+	case 4:
+		var status []string
+		if !result.Complete {
+			status = append(status, "incomplete")
+		}
+		if result.Mismatch {
+			status = append(status, "differs from overview")
+		}
+		return strings.Join(status, ", ")
+	case 5:
+		return result.TieBreak
+	}
+	return ""
+}
@@ -0,0 +1,503 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// RosterField is a Contestant or Juror field a roster column can be mapped to
+type RosterField string
+
+const (
+	rosterName      RosterField = "Name"
+	rosterFirstName RosterField = "First Name"
+	rosterLastName  RosterField = "Last Name"
+	rosterClub      RosterField = "Club / Team"
+	rosterEmail     RosterField = "Email"
+	rosterWeight    RosterField = "Weight"
+)
+
+// rosterFieldSynonyms lists the column headers recognized for each field, in lower case
+var rosterFieldSynonyms = map[RosterField][]string{
+	rosterName:      {"name", "full name", "contestant", "juror", "judge", "participant", "aufgussmeister"},
+	rosterFirstName: {"first name", "firstname", "given name", "forename", "vorname"},
+	rosterLastName:  {"last name", "lastname", "surname", "family name", "nachname"},
+	rosterClub:      {"club", "team", "verein", "company", "sauna", "organisation", "organization"},
+	rosterEmail:     {"email", "e-mail", "mail", "email address"},
+	rosterWeight:    {"weight", "weighting", "gewichtung", "weight %"},
+}
+
+// RosterMapping maps fields to zero-based column indexes. Unmapped fields are absent.
+type RosterMapping map[RosterField]int
+
+// Roster is a table read from a registration file
+type Roster struct {
+	Header     []string // Column names, generated if the file has no header row
+	Rows       [][]string
+	RowNumbers []int // One-based row number of each row in the file
+}
+
+// RosterEntry is a person read from a roster row
+type RosterEntry struct {
+	Row       int // One-based row number in the file, for messages
+	Name      string
+	Club      string
+	Email     string
+	Weight    int
+	HasWeight bool
+}
+
+// readRosterRows reads all rows of a CSV or XLSX file, depending on the extension
+func readRosterRows(data []byte, extension string) ([][]string, error) {
+	switch strings.ToLower(extension) {
+	case ".csv", ".txt":
+		return readCSVRows(data)
+	case ".xlsx":
+		return readXLSXRows(data)
+	default:
+		return nil, fmt.Errorf("Unsupported file type '%s'. Please use a CSV or XLSX file.", extension)
+	}
+}
+
+// readCSVRows reads a CSV file separated by commas, semicolons or tabs
+func readCSVRows(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 byte order mark written by Excel
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectCSVDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %v", err)
+	}
+	return rows, nil
+}
+
+// detectCSVDelimiter guesses the delimiter from the first line
+func detectCSVDelimiter(data []byte) rune {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	delimiter, best := ',', 0
+	for _, candidate := range []rune{',', ';', '\t'} {
+		if count := bytes.Count(firstLine, []byte(string(candidate))); count > best {
+			delimiter, best = candidate, count
+		}
+	}
+	return delimiter
+}
+
+// readXLSXRows reads the first worksheet of an XLSX workbook
+func readXLSXRows(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("the file is not a valid XLSX workbook: %v", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := firstXLSXSheet(files)
+	if err != nil {
+		return nil, err
+	}
+	sharedStrings, err := readXLSXSharedStrings(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sheet struct {
+		Rows []struct {
+			Number int `xml:"r,attr"`
+			Cells  []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline struct {
+					Text string `xml:",innerxml"`
+				} `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeXLSXPart(files, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	for _, xmlRow := range sheet.Rows {
+		for len(rows) < xmlRow.Number-1 {
+			rows = append(rows, []string{}) // Rows without cells are left out of the sheet
+		}
+		row := []string{}
+		for i, cell := range xmlRow.Cells {
+			column := i
+			if cell.Ref != "" {
+				if index, ok := xlsxColumnIndex(cell.Ref); ok {
+					column = index
+				}
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings) {
+					return nil, fmt.Errorf("invalid shared string reference in cell %s", cell.Ref)
+				}
+				value = sharedStrings[index]
+			case "inlineStr":
+				value = xlsxText(cell.Inline.Text)
+			case "b":
+				value = map[string]string{"0": "FALSE", "1": "TRUE"}[cell.Value]
+			}
+			row[column] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// firstXLSXSheet returns the archive path of the first worksheet of the workbook
+func firstXLSXSheet(files map[string]*zip.File) (string, error) {
+	var workbook struct {
+		Sheets []struct {
+			RelationID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeXLSXPart(files, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("the workbook contains no worksheets")
+	}
+
+	var relations struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXLSXPart(files, "xl/_rels/workbook.xml.rels", &relations); err != nil {
+		return "", err
+	}
+	for _, relation := range relations.Relationships {
+		if relation.ID == workbook.Sheets[0].RelationID {
+			if strings.HasPrefix(relation.Target, "/") {
+				return strings.TrimPrefix(relation.Target, "/"), nil
+			}
+			return path.Join("xl", relation.Target), nil
+		}
+	}
+	return "", fmt.Errorf("the first worksheet could not be found in the workbook")
+}
+
+// readXLSXSharedStrings returns the shared string table, which most cells refer to
+func readXLSXSharedStrings(files map[string]*zip.File) ([]string, error) {
+	if files["xl/sharedStrings.xml"] == nil {
+		return nil, nil
+	}
+	var table struct {
+		Items []struct {
+			Text string `xml:",innerxml"`
+		} `xml:"si"`
+	}
+	if err := decodeXLSXPart(files, "xl/sharedStrings.xml", &table); err != nil {
+		return nil, err
+	}
+	strs := make([]string, len(table.Items))
+	for i, item := range table.Items {
+		strs[i] = xlsxText(item.Text)
+	}
+	return strs, nil
+}
+
+// xlsxText joins the text runs of a rich text element, skipping phonetic hints
+func xlsxText(innerXML string) string {
+	var text struct {
+		Plain string `xml:"t"`
+		Runs  []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+	if err := xml.Unmarshal([]byte("<x>"+innerXML+"</x>"), &text); err != nil {
+		return ""
+	}
+	var builder strings.Builder
+	builder.WriteString(text.Plain)
+	for _, run := range text.Runs {
+		builder.WriteString(run.Text)
+	}
+	return builder.String()
+}
+
+func decodeXLSXPart(files map[string]*zip.File, name string, target interface{}) error {
+	file := files[name]
+	if file == nil {
+		return fmt.Errorf("the workbook is missing %s", name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %v", name, err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", name, err)
+	}
+	if err := xml.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", name, err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex returns the zero-based column of a cell reference like "C12"
+func xlsxColumnIndex(ref string) (int, bool) {
+	index := 0
+	letters := 0
+	for _, r := range strings.ToUpper(ref) {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+		letters++
+	}
+	return index - 1, letters > 0
+}
+
+// xlsxColumnName returns the spreadsheet column name of a zero-based index, like "C"
+func xlsxColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// newRoster turns the rows of a file into a roster. Empty rows are dropped and all
+// rows are padded to the same width.
+func newRoster(rows [][]string, hasHeader bool) Roster {
+	roster := Roster{}
+	width := 0
+	for number, row := range rows {
+		empty := true
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+			if row[i] != "" {
+				empty = false
+			}
+		}
+		if empty {
+			continue
+		}
+		roster.Rows = append(roster.Rows, row)
+		roster.RowNumbers = append(roster.RowNumbers, number+1)
+		width = max(width, len(row))
+	}
+	for i, row := range roster.Rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		roster.Rows[i] = row
+	}
+
+	if hasHeader && len(roster.Rows) > 0 {
+		roster.Header = roster.Rows[0]
+		roster.Rows = roster.Rows[1:]
+		roster.RowNumbers = roster.RowNumbers[1:]
+	} else {
+		roster.Header = make([]string, width)
+	}
+	for i := range roster.Header {
+		if roster.Header[i] == "" {
+			roster.Header[i] = fmt.Sprintf("Column %s", xlsxColumnName(i))
+		}
+	}
+	return roster
+}
+
+// guessRosterMapping maps the roster columns to fields based on their headers
+func guessRosterMapping(roster Roster, fields []RosterField) RosterMapping {
+	mapping := RosterMapping{}
+	for _, field := range fields {
+		for column, header := range roster.Header {
+			if containsString(rosterFieldSynonyms[field], normalizeRosterName(header)) {
+				mapping[field] = column
+				break
+			}
+		}
+	}
+
+	// Without recognizable headers, assume the names are in the first column
+	_, hasName := mapping[rosterName]
+	_, hasFirst := mapping[rosterFirstName]
+	_, hasLast := mapping[rosterLastName]
+	if !hasName && !hasFirst && !hasLast && len(roster.Header) > 0 {
+		mapping[rosterName] = 0
+	}
+	return mapping
+}
+
+// rosterEntries reads the mapped fields of every row. Rows that cannot be imported are
+// reported as problems.
+func rosterEntries(roster Roster, mapping RosterMapping) ([]RosterEntry, []string) {
+	value := func(row []string, field RosterField) string {
+		column, mapped := mapping[field]
+		if !mapped || column < 0 || column >= len(row) {
+			return ""
+		}
+		return row[column]
+	}
+
+	// Whether the weights are fractions is decided for the whole column, not per value
+	var weights []string
+	for _, row := range roster.Rows {
+		weights = append(weights, value(row, rosterWeight))
+	}
+	fractions := rosterWeightsAreFractions(weights)
+
+	entries := []RosterEntry{}
+	problems := []string{}
+	for i, row := range roster.Rows {
+		entry := RosterEntry{
+			Row:   roster.RowNumbers[i],
+			Name:  value(row, rosterName),
+			Club:  value(row, rosterClub),
+			Email: value(row, rosterEmail),
+		}
+		if entry.Name == "" {
+			entry.Name = strings.TrimSpace(value(row, rosterFirstName) + " " + value(row, rosterLastName))
+		}
+		if entry.Name == "" {
+			problems = append(problems, fmt.Sprintf("Row %d has no name and is skipped", entry.Row))
+			continue
+		}
+		if text := value(row, rosterWeight); text != "" {
+			weight, err := parseRosterWeight(text, fractions)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("Row %d: invalid weight '%s'", entry.Row, text))
+			} else {
+				entry.Weight, entry.HasWeight = weight, true
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, problems
+}
+
+// rosterWeightsAreFractions reports whether a weight column holds fractions, as written by
+// spreadsheets formatted as percent: every number without a percent sign is at most 1 and
+// at least one is above 0. Empty and invalid values are ignored.
+func rosterWeightsAreFractions(values []string) bool {
+	fractions := false
+	for _, text := range values {
+		text = strings.TrimSpace(text)
+		if text == "" || strings.HasSuffix(text, "%") {
+			continue
+		}
+		number, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil {
+			continue
+		}
+		if number > 1 {
+			return false
+		}
+		fractions = fractions || number > 0
+	}
+	return fractions
+}
+
+// parseRosterWeight reads weights like "25", "25%" or "25,0" as whole percentages. In a
+// column of fractions (see rosterWeightsAreFractions), "0.25" is read as 25% as well.
+func parseRosterWeight(text string, fractions bool) (int, error) {
+	text = strings.TrimSpace(text)
+	percent := strings.HasSuffix(text, "%")
+	text = strings.TrimSpace(strings.TrimSuffix(text, "%"))
+	number, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid weight '%s'", text)
+	}
+	if fractions && !percent {
+		number *= 100
+	}
+	return int(math.Round(number)), nil
+}
+
+// normalizeRosterName returns the form of a name used to detect duplicates
+func normalizeRosterName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// findRosterDuplicates returns, per entry index, why the entry is a duplicate: either an
+// earlier row of the file has the same name, or the name is already in the existing list.
+func findRosterDuplicates(entries []RosterEntry, existing []string) map[int]string {
+	duplicates := make(map[int]string)
+	existingNames := make(map[string]bool)
+	for _, name := range existing {
+		existingNames[normalizeRosterName(name)] = true
+	}
+	seen := make(map[string]int)
+	for i, entry := range entries {
+		name := normalizeRosterName(entry.Name)
+		if row, exists := seen[name]; exists {
+			duplicates[i] = fmt.Sprintf("Duplicate of row %d", row)
+		} else if existingNames[name] {
+			duplicates[i] = "Already in the list"
+		}
+		if _, exists := seen[name]; !exists {
+			seen[name] = entry.Row
+		}
+	}
+	return duplicates
+}
+
+// importRosterContestants returns the contestant list after importing the entries.
+// Entries listed in skip are left out.
+func importRosterContestants(existing []*Contestant, entries []RosterEntry, skip map[int]string, replace bool) []*Contestant {
+	result := []*Contestant{}
+	if !replace {
+		result = append(result, existing...)
+	}
+	for i, entry := range entries {
+		if _, skipped := skip[i]; skipped {
+			continue
+		}
+		result = append(result, &Contestant{Name: entry.Name, Team: entry.Club, Email: entry.Email})
+	}
+	return result
+}
+
+// importRosterJurors returns the jury after importing the entries. Entries listed in
+// skip are left out.
+func importRosterJurors(existing []*Juror, entries []RosterEntry, skip map[int]string, replace bool) []*Juror {
+	result := []*Juror{}
+	if !replace {
+		result = append(result, existing...)
+	}
+	for i, entry := range entries {
+		if _, skipped := skip[i]; skipped {
+			continue
+		}
+		result = append(result, &Juror{Name: entry.Name, Weight: entry.Weight, Email: entry.Email, Club: entry.Club})
+	}
+	return result
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	scheduleTimeLayout        = "2006-01-02 15:04" // Layout used to enter and display slot start times
+	defaultPerformanceMinutes = 15                 // Used when no performance duration is set
+)
+
+// Schedule holds the settings shared by all time slots of a competition
+type Schedule struct {
+	Saunas             []string `json:"saunas"`
+	PerformanceMinutes int      `json:"performance_minutes"`
+	BreakMinutes       int      `json:"break_minutes"`
+}
+
+// TimeSlot places a contestant's aufguss in a sauna at a given time
+type TimeSlot struct {
+	Start time.Time `json:"start"`
+	Sauna string    `json:"sauna"`
+}
+
+// ScheduleConflict describes two contestants whose slots overlap in the same sauna
+type ScheduleConflict struct {
+	Sauna  string
+	First  *Contestant
+	Second *Contestant
+}
+
+func (c ScheduleConflict) String() string {
+	return fmt.Sprintf("%s: '%s' (%s) overlaps '%s' (%s)", c.Sauna,
+		c.First.Name, c.First.Slot.Start.Format("15:04"),
+		c.Second.Name, c.Second.Slot.Start.Format("15:04"))
+}
+
+// Performance returns the duration of a single aufguss
+func (s Schedule) Performance() time.Duration {
+	if s.PerformanceMinutes <= 0 {
+		return defaultPerformanceMinutes * time.Minute
+	}
+	return time.Duration(s.PerformanceMinutes) * time.Minute
+}
+
+// Break returns the pause required between two aufguss in the same sauna
+func (s Schedule) Break() time.Duration {
+	if s.BreakMinutes < 0 {
+		return 0
+	}
+	return time.Duration(s.BreakMinutes) * time.Minute
+}
+
+// SlotEnd returns the time a slot's performance ends
+func (s Schedule) SlotEnd(slot *TimeSlot) time.Time {
+	return slot.Start.Add(s.Performance())
+}
+
+// findScheduleConflicts returns every pair of slots in the same sauna that overlap,
+// including the break that must follow each performance.
+func findScheduleConflicts(schedule Schedule, contestants []*Contestant) []ScheduleConflict {
+	bySauna := make(map[string][]*Contestant)
+	saunas := []string{}
+	for _, contestant := range contestants {
+		if contestant.Slot == nil || contestant.Slot.Start.IsZero() {
+			continue
+		}
+		sauna := strings.TrimSpace(contestant.Slot.Sauna)
+		if _, exists := bySauna[sauna]; !exists {
+			saunas = append(saunas, sauna)
+		}
+		bySauna[sauna] = append(bySauna[sauna], contestant)
+	}
+
+	conflicts := []ScheduleConflict{}
+	for _, sauna := range saunas {
+		slotted := bySauna[sauna]
+		sort.SliceStable(slotted, func(i, j int) bool {
+			return slotted[i].Slot.Start.Before(slotted[j].Slot.Start)
+		})
+		for i, first := range slotted {
+			blockedUntil := schedule.SlotEnd(first.Slot).Add(schedule.Break())
+			for _, second := range slotted[i+1:] {
+				if !second.Slot.Start.Before(blockedUntil) {
+					break
+				}
+				conflicts = append(conflicts, ScheduleConflict{Sauna: sauna, First: first, Second: second})
+			}
+		}
+	}
+	return conflicts
+}
+
+// runningOrder returns the contestants sorted by slot start time. Contestants without
+// a slot keep their relative order and are placed after the scheduled ones.
+func runningOrder(contestants []*Contestant) []*Contestant {
+	ordered := make([]*Contestant, len(contestants))
+	copy(ordered, contestants)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i].Slot, ordered[j].Slot
+		aScheduled := a != nil && !a.Start.IsZero()
+		bScheduled := b != nil && !b.Start.IsZero()
+		if aScheduled != bScheduled {
+			return aScheduled
+		}
+		if !aScheduled {
+			return false
+		}
+		return a.Start.Before(b.Start)
+	})
+	return ordered
+}
+
+// hasSchedule reports whether at least one contestant has a time slot
+func hasSchedule(contestants []*Contestant) bool {
+	for _, contestant := range contestants {
+		if contestant.Slot != nil && !contestant.Slot.Start.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// autoFillSchedule assigns consecutive slots to the contestants in their current order,
+// starting at start and distributing them over the saunas in turn.
+func autoFillSchedule(schedule Schedule, contestants []*Contestant, start time.Time) {
+	saunas := schedule.Saunas
+	if len(saunas) == 0 {
+		saunas = []string{""}
+	}
+	step := schedule.Performance() + schedule.Break()
+	for i, contestant := range contestants {
+		contestant.Slot = &TimeSlot{
+			Start: start.Add(time.Duration(i/len(saunas)) * step),
+			Sauna: saunas[i%len(saunas)],
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Schedule Editor Window Function
+func showScheduleEditor(myApp fyne.App, history *UndoHistory, schedule *Schedule, contestants *[]*Contestant) {
+	scheduleWindow := myApp.NewWindow("Schedule")
+	scheduleWindow.Resize(fyne.NewSize(700, 500))
+
+	// The edits are undone together: the schedule settings and the slots of the contestants
+	type scheduleState struct {
+		Schedule Schedule
+		Slots    map[*Contestant]*TimeSlot
+	}
+	recordWindowEdits(history, scheduleWindow, "Edit Schedule", func() scheduleState {
+		state := scheduleState{Schedule: *schedule, Slots: make(map[*Contestant]*TimeSlot)}
+		state.Schedule.Saunas = append([]string(nil), schedule.Saunas...)
+		contestantsMutex.RLock()
+		defer contestantsMutex.RUnlock()
+		for _, contestant := range *contestants {
+			if contestant.Slot != nil {
+				slot := *contestant.Slot
+				state.Slots[contestant] = &slot
+			} else {
+				state.Slots[contestant] = nil
+			}
+		}
+		return state
+	}, func(state scheduleState) {
+		*schedule = state.Schedule
+		schedule.Saunas = append([]string(nil), state.Schedule.Saunas...)
+		contestantsMutex.Lock()
+		defer contestantsMutex.Unlock()
+		for contestant, slot := range state.Slots {
+			contestant.Slot = nil
+			if slot != nil {
+				copied := *slot
+				contestant.Slot = &copied
+			}
+		}
+	})
+
+	// Conflict list shown below the slots
+	conflictText := widget.NewLabel("")
+	conflictText.Wrapping = fyne.TextWrapWord
+	conflictHeader := canvas.NewText("", color.RGBA{R: 255, G: 0, B: 0, A: 255})
+	conflictHeader.TextSize = 12
+
+	updateConflicts := func() {
+		contestantsMutex.RLock()
+		conflicts := findScheduleConflicts(*schedule, *contestants)
+		contestantsMutex.RUnlock()
+
+		if len(conflicts) == 0 {
+			conflictHeader.Text = "No conflicts."
+			conflictHeader.Color = color.RGBA{R: 0, G: 128, B: 0, A: 255} // Green
+			conflictText.SetText("")
+		} else {
+			lines := make([]string, len(conflicts))
+			for i, conflict := range conflicts {
+				lines[i] = conflict.String()
+			}
+			conflictHeader.Text = fmt.Sprintf("%d conflict(s):", len(conflicts))
+			conflictHeader.Color = color.RGBA{R: 255, G: 0, B: 0, A: 255} // Red
+			conflictText.SetText(strings.Join(lines, "\n"))
+		}
+		conflictHeader.Refresh()
+	}
+
+	// Sauna names, comma separated
+	saunasEntry := widget.NewEntry()
+	saunasEntry.SetPlaceHolder("e.g. Main Sauna, Garden Sauna")
+	saunasEntry.SetText(strings.Join(schedule.Saunas, ", "))
+
+	// Durations
+	performanceEntry := widget.NewEntry()
+	performanceEntry.SetText(strconv.Itoa(int(schedule.Performance() / time.Minute)))
+	performanceEntry.OnChanged = func(text string) {
+		if minutes, err := strconv.Atoi(strings.TrimSpace(text)); err == nil && minutes > 0 {
+			schedule.PerformanceMinutes = minutes
+			updateConflicts()
+		}
+	}
+	breakEntry := widget.NewEntry()
+	breakEntry.SetText(strconv.Itoa(schedule.BreakMinutes))
+	breakEntry.OnChanged = func(text string) {
+		if minutes, err := strconv.Atoi(strings.TrimSpace(text)); err == nil && minutes >= 0 {
+			schedule.BreakMinutes = minutes
+			updateConflicts()
+		}
+	}
+
+	// One row per contestant with sauna and start time
+	slotRows := container.NewVBox()
+	var rebuildRows func()
+	rebuildRows = func() {
+		contestantsMutex.RLock()
+		ordered := runningOrder(*contestants)
+		contestantsMutex.RUnlock()
+
+		slotRows.RemoveAll()
+		for i, contestant := range ordered {
+			contestant := contestant
+
+			saunaSelect := widget.NewSelect(schedule.Saunas, nil)
+			saunaSelect.PlaceHolder = "Sauna"
+			startEntry := widget.NewEntry()
+			startEntry.SetPlaceHolder(scheduleTimeLayout)
+			if contestant.Slot != nil {
+				saunaSelect.SetSelected(contestant.Slot.Sauna)
+				if !contestant.Slot.Start.IsZero() {
+					startEntry.SetText(contestant.Slot.Start.Format(scheduleTimeLayout))
+				}
+			}
+
+			saunaSelect.OnChanged = func(selected string) {
+				contestantsMutex.Lock()
+				if contestant.Slot == nil {
+					contestant.Slot = &TimeSlot{}
+				}
+				contestant.Slot.Sauna = selected
+				contestantsMutex.Unlock()
+				updateConflicts()
+			}
+			startEntry.OnChanged = func(text string) {
+				start, err := time.ParseInLocation(scheduleTimeLayout, strings.TrimSpace(text), time.Local)
+				if err != nil && strings.TrimSpace(text) != "" {
+					return // Wait until the entry holds a complete time
+				}
+				contestantsMutex.Lock()
+				if contestant.Slot == nil {
+					contestant.Slot = &TimeSlot{}
+				}
+				contestant.Slot.Start = start
+				contestantsMutex.Unlock()
+				updateConflicts()
+			}
+			startEntry.OnSubmitted = func(string) {
+				rebuildRows() // Re-sort into running order
+			}
+
+			slotRows.Add(container.NewGridWithColumns(3,
+				widget.NewLabel(fmt.Sprintf("%d. %s", i+1, contestant.Name)),
+				saunaSelect,
+				startEntry,
+			))
+		}
+		slotRows.Refresh()
+	}
+
+	saunasEntry.OnChanged = func(text string) {
+		saunas := []string{}
+		for _, sauna := range strings.Split(text, ",") {
+			if sauna = strings.TrimSpace(sauna); sauna != "" {
+				saunas = append(saunas, sauna)
+			}
+		}
+		schedule.Saunas = saunas
+	}
+	saunasEntry.OnSubmitted = func(string) {
+		rebuildRows()
+	}
+
+	// Auto-fill slots from a start time
+	firstStartEntry := widget.NewEntry()
+	firstStartEntry.SetPlaceHolder(scheduleTimeLayout)
+	autoFillButton := widget.NewButton("Auto-fill", func() {
+		start, err := time.ParseInLocation(scheduleTimeLayout, strings.TrimSpace(firstStartEntry.Text), time.Local)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("Invalid start time. Use the format %s.", scheduleTimeLayout), scheduleWindow)
+			return
+		}
+		contestantsMutex.Lock()
+		autoFillSchedule(*schedule, *contestants, start)
+		contestantsMutex.Unlock()
+		rebuildRows()
+		updateConflicts()
+	})
+
+	rebuildRows()
+	updateConflicts()
+
+	scheduleWindow.SetContent(container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle("Saunas:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			saunasEntry,
+			container.NewGridWithColumns(2,
+				widget.NewLabelWithStyle("Performance (minutes):", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Break (minutes):", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				performanceEntry,
+				breakEntry,
+			),
+			container.NewBorder(nil, nil,
+				widget.NewLabelWithStyle("First slot:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				autoFillButton,
+				firstStartEntry,
+			),
+			widget.NewSeparator(),
+		),
+		container.NewVBox(
+			widget.NewSeparator(),
+			conflictHeader,
+			conflictText,
+			container.NewHBox(
+				layout.NewSpacer(),
+				widget.NewButton("Close", func() {
+					scheduleWindow.Close()
+				}),
+			),
+		),
+		nil, nil,
+		container.NewVScroll(slotRows),
+	))
+	scheduleWindow.Show()
+}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const scoreTolerance = 0.005 // Scores closer than this count as equal, as the sheets show two decimals
+
+// ContestantResult is the weighted result of one contestant
+type ContestantResult struct {
+	Contestant      string
+	Sheet           string
+	Total           float64
+	CriterionScores []float64  // Jury-weighted score per criterion of the competition
+	JurorTotals     []*float64 // Total of each juror, nil if the juror did not score
+	Complete        bool       // Every juror scored every criterion they are assigned
+	Rank            int        // 1-based, equal for contestants sharing a placing
+	TieBreak        string     // Rule that decided the placing among contestants with the same total
+	Overview        *float64   // Result read from the overview spreadsheet, if cross-checked
+	Mismatch        bool       // The overview shows a different result
+}
+
+// Standings are the results of all contestants, best first
+type Standings struct {
+	Aggregation   string // Display name of the aggregation mode
+	LowerIsBetter bool   // Totals are sums of ranks
+	Criteria      []string
+	Jurors        []string
+	Results       []*ContestantResult
+	Warnings      []string
+}
+
+// computeStandings weighs the fetched scores and ranks the contestants.
+//
+// Without criteria, a contestant's total is the sum of the juror totals multiplied by the
+// juror shares (see effectiveJurorShares). With criteria, each criterion is scored by the
+// jury-weighted points of the jurors assigned to it (see effectiveJurorWeights), and the
+// total is the sum of the criterion scores multiplied by the criterion weights.
+//
+// The other aggregation modes count the active jurors equally (see activeJurors). The trimmed
+// mean and the median are taken of the juror totals, which the Overview adds up the same way
+// (see addAggregationFormulas), and, for the criterion scores, of the points per criterion.
+// The sum of ranks adds up the placings the jurors give by their totals; the lowest sum
+// ranks first.
+func computeStandings(comp Competition, results *CompetitionResults) *Standings {
+	mode := aggregationMode(comp)
+	standings := &Standings{Aggregation: aggregationName(mode)}
+	for _, criterion := range comp.Criteria {
+		standings.Criteria = append(standings.Criteria, criterion.Name)
+	}
+
+	// Jurors are matched by name, as the jury may have changed since the generation
+	jurors := make([]*Juror, len(results.Jurors))
+	for i, scores := range results.Jurors {
+		standings.Jurors = append(standings.Jurors, scores.Juror)
+		for _, juror := range comp.Jury {
+			if strings.EqualFold(strings.TrimSpace(juror.Name), strings.TrimSpace(scores.Juror)) {
+				jurors[i] = juror
+				break
+			}
+		}
+		if jurors[i] == nil {
+			jurors[i] = &Juror{Name: scores.Juror}
+			standings.Warnings = append(standings.Warnings, fmt.Sprintf("Juror '%s' is no longer in the jury and is weighted with 0%%.", scores.Juror))
+		}
+		if scores.Error != "" {
+			standings.Warnings = append(standings.Warnings, fmt.Sprintf("The spreadsheet of %s could not be read: %s", scores.Juror, scores.Error))
+		}
+	}
+	matrix := effectiveJurorWeights(jurors, comp.Criteria)
+	shares := effectiveJurorShares(jurors, comp.Criteria)
+	active := activeJurors(jurors)
+
+	for _, tab := range contestantTabs(comp, results) {
+		result := &ContestantResult{
+			Contestant:      tab.Contestant,
+			Sheet:           tab.Sheet,
+			CriterionScores: make([]float64, len(comp.Criteria)),
+			JurorTotals:     make([]*float64, len(jurors)),
+			Complete:        true,
+		}
+		jurorPoints := make([][]*float64, len(jurors))
+		for j, juror := range jurors {
+			scores, ok := results.Scores(j, tab.Contestant)
+			if total, scored := scores.Total(); ok && scored {
+				result.JurorTotals[j] = &total
+			}
+			if len(comp.Criteria) == 0 {
+				if result.JurorTotals[j] == nil {
+					result.Complete = result.Complete && juror.Weight == 0
+					continue
+				}
+				result.Total += shares[j] * *result.JurorTotals[j]
+				result.Complete = result.Complete && scores.Complete()
+				continue
+			}
+
+			points := criterionPoints(scores, comp.Criteria)
+			jurorPoints[j] = points
+			for c := range comp.Criteria {
+				if matrix[j][c] == 0 {
+					continue
+				}
+				if points[c] == nil {
+					result.Complete = false
+					continue
+				}
+				result.CriterionScores[c] += matrix[j][c] * *points[c]
+			}
+		}
+		for c, criterion := range comp.Criteria {
+			result.Total += float64(criterion.Weight) / 100 * result.CriterionScores[c]
+		}
+
+		switch mode {
+		case aggregationTrimmedMean, aggregationMedian:
+			for c := range comp.Criteria {
+				points := make([]*float64, len(jurors))
+				for j := range jurors {
+					if jurorPoints[j] != nil {
+						points[j] = jurorPoints[j][c]
+					}
+				}
+				result.CriterionScores[c] = aggregateScores(mode, trimCount(comp), points, active)
+			}
+			result.Total = aggregateScores(mode, trimCount(comp), result.JurorTotals, active)
+		case aggregationRankSum:
+			result.Total = 0
+		}
+		standings.Results = append(standings.Results, result)
+	}
+
+	if mode == aggregationRankSum {
+		applyRankSums(standings.Results, active)
+		standings.LowerIsBetter = true
+		for _, result := range standings.Results {
+			if !result.Complete {
+				standings.Warnings = append(standings.Warnings, "Some contestants were not scored by every juror. Their sums of ranks are lower and not comparable.")
+				break
+			}
+		}
+	}
+
+	rankResults(standings.Results, standings.LowerIsBetter, newTieBreaker(comp.TieBreaks, standings.Criteria, standings.Jurors, standings.Results))
+	return standings
+}
+
+// contestantTabs returns the contestants that were scored, in running order
+func contestantTabs(comp Competition, results *CompetitionResults) []ContestantTab {
+	if comp.Generation != nil && len(comp.Generation.Tabs) > 0 {
+		return comp.Generation.Tabs
+	}
+	var tabs []ContestantTab
+	if len(results.Jurors) > 0 {
+		for _, scores := range results.Jurors[0].Contestants {
+			tabs = append(tabs, ContestantTab{Contestant: scores.Contestant, Sheet: scores.Sheet})
+		}
+	}
+	return tabs
+}
+
+// criterionPoints returns the points a juror gave per criterion. Points are matched to the
+// criteria by the labels above them (see matchCriteria).
+func criterionPoints(scores ContestantScores, criteria []*Criterion) []*float64 {
+	var labels []string
+	var points []*float64
+	for _, line := range scores.Rows {
+		labels = append(labels, line.Labels...)
+		points = append(points, line.Points...)
+	}
+
+	matched := make([]*float64, len(criteria))
+	for c, i := range matchCriteria(labels, criteria) {
+		if i >= 0 {
+			matched[c] = points[i]
+		}
+	}
+	return matched
+}
+
+// matchCriteria returns for each criterion the index of the point labeled with its name, or
+// the criterion's position if the labels do not name every criterion. The index is -1 if
+// there are fewer points than criteria.
+func matchCriteria(labels []string, criteria []*Criterion) []int {
+	matched := make([]int, len(criteria))
+	byLabel := true
+	for c, criterion := range criteria {
+		matched[c] = -1
+		for i, label := range labels {
+			if strings.EqualFold(strings.TrimSuffix(strings.TrimSpace(label), ":"), strings.TrimSpace(criterion.Name)) {
+				matched[c] = i
+				break
+			}
+		}
+		byLabel = byLabel && matched[c] >= 0
+	}
+	if byLabel {
+		return matched
+	}
+
+	for c := range criteria {
+		matched[c] = -1
+		if c < len(labels) {
+			matched[c] = c
+		}
+	}
+	return matched
+}
+
+// rankResults sorts the results by total, best first, and assigns the placings. Totals are
+// compared as shown, rounded to two decimals, so the order is consistent. Ties are settled
+// by the tie-break rules; contestants they cannot separate share a placing.
+func rankResults(results []*ContestantResult, lowerIsBetter bool, breaker *tieBreaker) {
+	sort.SliceStable(results, func(a, b int) bool {
+		if lowerIsBetter {
+			return roundScore(results[a].Total) < roundScore(results[b].Total)
+		}
+		return roundScore(results[a].Total) > roundScore(results[b].Total)
+	})
+
+	var groups [][]*ContestantResult
+	for i := 0; i < len(results); {
+		end := i + 1
+		for end < len(results) && roundScore(results[end].Total) == roundScore(results[i].Total) {
+			end++
+		}
+		tied := append([]*ContestantResult(nil), results[i:end]...)
+		groups = append(groups, breaker.resolve(tied, 0)...)
+		i = end
+	}
+
+	position := 0
+	for _, group := range groups {
+		rank := position + 1
+		for _, result := range group {
+			result.Rank = rank
+			results[position] = result
+			position++
+		}
+	}
+}
+
+// sameScore reports whether two scores are equal within the tolerance
+func sameScore(a, b float64) bool {
+	return math.Abs(a-b) < scoreTolerance
+}
+
+// roundScore rounds a score to the two decimals shown
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
+
+// formatScore formats a score with at most two decimals
+func formatScore(score float64) string {
+	return strconv.FormatFloat(roundScore(score), 'f', -1, 64)
+}
+
+// totalLabel returns the column header of the totals
+func (s *Standings) totalLabel() string {
+	if s.LowerIsBetter {
+		return "Sum of Ranks"
+	}
+	return "Total"
+}
+
+// applyOverviewTotals compares the results with the totals read from the overview
+// spreadsheet and flags every contestant whose result differs
+func (s *Standings) applyOverviewTotals(totals map[string]*float64) {
+	for _, result := range s.Results {
+		overview, ok := totals[result.Sheet]
+		if !ok || overview == nil {
+			continue
+		}
+		result.Overview = overview
+		result.Mismatch = !sameScore(result.Total, *overview)
+		if result.Mismatch {
+			s.Warnings = append(s.Warnings, fmt.Sprintf("%s: the overview shows %s, the local result is %s.",
+				result.Contestant, formatScore(*overview), formatScore(result.Total)))
+		}
+	}
+}
+
+const overviewScanRows = 200 // Rows of each overview tab searched for the result
+
+// overviewResultLabels mark the cell left of a contestant's result in the overview template
+var overviewResultLabels = []string{"Result:", "Final Score:", "Weighted Total:", "Score:"}
+
+// crossCheckOverview reads the results the overview formulas computed and compares them with
+// the standings. Juror totals imported into the overview are compared with the juror
+// spreadsheets as well. Differences are added to the warnings.
+func crossCheckOverview(ctx context.Context, credentials string, manifest *GenerationManifest, results *CompetitionResults, standings *Standings) error {
+	services, err := initializeGoogleServices(ctx, credentials)
+	if err != nil {
+		return err
+	}
+	tabs, err := readContestantTabs(ctx, services.Sheets, manifest.OverviewID, manifest.Tabs, overviewScanRows)
+	if err != nil {
+		return err
+	}
+
+	totals := make(map[string]*float64)
+	missing := 0
+	for t, tab := range manifest.Tabs {
+		totals[tab.Sheet] = findOverviewResult(tabs[t])
+		if totals[tab.Sheet] == nil {
+			missing++
+		}
+
+		// Juror rows were inserted below each "Points:" row in jury order
+		jurorCount := len(manifest.JurorSheets)
+		for p, info := range manifest.PointsRows {
+			end := columnLetterToIndex(info.EndColumn)
+			for j := 0; j < jurorCount; j++ {
+				row := info.Row - 1 + p*(jurorCount-1) + j
+				if row >= len(tabs[t]) || end+1 >= len(tabs[t][row]) {
+					continue
+				}
+				overview := cellNumber(tabs[t][row][end+1])
+				scores, ok := results.Scores(j, tab.Contestant)
+				if !ok || overview == nil || p >= len(scores.Rows) || scores.Rows[p].Total == nil {
+					continue
+				}
+				if !sameScore(*overview, *scores.Rows[p].Total) {
+					standings.Warnings = append(standings.Warnings, fmt.Sprintf("%s, %s: the overview shows a total of %s, the juror spreadsheet %s.",
+						tab.Contestant, manifest.JurorSheets[j].Juror, formatScore(*overview), formatScore(*scores.Rows[p].Total)))
+				}
+			}
+		}
+	}
+	if missing > 0 {
+		standings.Warnings = append(standings.Warnings, fmt.Sprintf("No result was found in %d overview tab(s). Label the result cell with one of: %s",
+			missing, strings.Join(overviewResultLabels, ", ")))
+	}
+	standings.applyOverviewTotals(totals)
+	return nil
+}
+
+// findOverviewResult returns the number right of the first result label in a tab
+func findOverviewResult(values [][]interface{}) *float64 {
+	for _, row := range values {
+		for col, value := range row {
+			text, ok := value.(string)
+			if !ok {
+				continue
+			}
+			for _, label := range overviewResultLabels {
+				if !strings.EqualFold(strings.TrimSpace(text), label) {
+					continue
+				}
+				for _, next := range row[col+1:] {
+					if number := cellNumber(next); number != nil {
+						return number
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// standingsCSV exports the ranking with the criterion scores and the deciding tie-break rule
+func standingsCSV(standings *Standings) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	header := []string{"Rank", "Contestant", standings.totalLabel()}
+	header = append(header, standings.Criteria...)
+	header = append(header, "Complete", "Tie-Break")
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, result := range standings.Results {
+		record := []string{fmt.Sprintf("%d", result.Rank), result.Contestant, formatScore(result.Total)}
+		for _, score := range result.CriterionScores {
+			record = append(record, formatScore(score))
+		}
+		complete := "yes"
+		if !result.Complete {
+			complete = "no"
+		}
+		record = append(record, complete, result.TieBreak)
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
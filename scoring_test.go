@@ -0,0 +1,159 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// scoreLine returns a "Points:" row with the given points, NaN leaving a point blank. The
+// total is the sum of the points entered.
+func scoreLine(labels []string, values ...float64) ScoreLine {
+	line := ScoreLine{Labels: labels}
+	total, scored := 0.0, false
+	for _, value := range values {
+		if math.IsNaN(value) {
+			line.Points = append(line.Points, nil)
+			continue
+		}
+		value := value
+		line.Points = append(line.Points, &value)
+		total += value
+		scored = true
+	}
+	if scored {
+		line.Total = &total
+	}
+	return line
+}
+
+// jurorTabs returns the scores one juror gave, one "Points:" row per contestant in order
+func jurorTabs(juror string, contestants []string, lines ...ScoreLine) JurorScores {
+	scores := JurorScores{Juror: juror}
+	for i, contestant := range contestants {
+		tab := ContestantScores{Contestant: contestant, Sheet: contestant}
+		if i < len(lines) && lines[i].Total != nil {
+			tab.Rows = []ScoreLine{lines[i]}
+		}
+		scores.Contestants = append(scores.Contestants, tab)
+	}
+	return scores
+}
+
+func TestComputeStandings(t *testing.T) {
+	contestants := []string{"Clara", "David"}
+	blank := math.NaN()
+	criteria := []string{"Technique", "Show"}
+
+	tests := []struct {
+		name     string
+		comp     Competition
+		results  *CompetitionResults
+		order    []string  // Contestants best first
+		totals   []float64 // In the same order
+		complete []bool
+	}{
+		{
+			name: "weighted mean of the juror totals",
+			comp: Competition{Jury: []*Juror{{Name: "Anna", Weight: 60}, {Name: "Ben", Weight: 40}}},
+			results: &CompetitionResults{Jurors: []JurorScores{
+				jurorTabs("Anna", contestants, scoreLine(nil, 8), scoreLine(nil, 7)),
+				jurorTabs("Ben", contestants, scoreLine(nil, 6), scoreLine(nil, 9)),
+			}},
+			order:    []string{"David", "Clara"},
+			totals:   []float64{7.8, 7.2},
+			complete: []bool{true, true},
+		},
+		{
+			name: "juror weights not adding up to 100 are rescaled",
+			comp: Competition{Jury: []*Juror{{Name: "Anna", Weight: 30}, {Name: "Ben", Weight: 20}}},
+			results: &CompetitionResults{Jurors: []JurorScores{
+				jurorTabs("Anna", contestants, scoreLine(nil, 8), scoreLine(nil, 7)),
+				jurorTabs("Ben", contestants, scoreLine(nil, 6), scoreLine(nil, 9)),
+			}},
+			order:    []string{"David", "Clara"},
+			totals:   []float64{7.8, 7.2},
+			complete: []bool{true, true},
+		},
+		{
+			name: "criteria scored by part of the jury",
+			comp: Competition{
+				Jury:     []*Juror{{Name: "Anna", Weight: 50}, {Name: "Ben", Weight: 50, Criteria: []string{"Show"}}},
+				Criteria: []*Criterion{{Name: "Technique", Weight: 60}, {Name: "Show", Weight: 40}},
+			},
+			results: &CompetitionResults{Jurors: []JurorScores{
+				jurorTabs("Anna", contestants, scoreLine(criteria, 8, 6), scoreLine(criteria, 9, 5)),
+				jurorTabs("Ben", contestants, scoreLine(criteria, blank, 10), scoreLine(criteria, blank, 7)),
+			}},
+			// Technique is Anna's alone, Show the mean of both: Clara 0.6*8 + 0.4*8, David 0.6*9 + 0.4*6
+			order:    []string{"Clara", "David"},
+			totals:   []float64{8, 7.8},
+			complete: []bool{true, true},
+		},
+		{
+			name: "missing scores leave the result incomplete",
+			comp: Competition{Jury: []*Juror{{Name: "Anna", Weight: 50}, {Name: "Ben", Weight: 50}}},
+			results: &CompetitionResults{Jurors: []JurorScores{
+				jurorTabs("Anna", contestants, scoreLine(nil, 8), scoreLine(nil, 9)),
+				jurorTabs("Ben", contestants, scoreLine(nil, 6)),
+			}},
+			order:    []string{"Clara", "David"},
+			totals:   []float64{7, 4.5},
+			complete: []bool{true, false},
+		},
+		{
+			name: "sum of ranks ranks the lowest sum first",
+			comp: Competition{
+				Aggregation: aggregationRankSum,
+				Jury:        []*Juror{{Name: "Anna", Weight: 50}, {Name: "Ben", Weight: 50}, {Name: "Cleo", Weight: 0}},
+			},
+			results: &CompetitionResults{Jurors: []JurorScores{
+				jurorTabs("Anna", contestants, scoreLine(nil, 8), scoreLine(nil, 7)),
+				jurorTabs("Ben", contestants, scoreLine(nil, 6), scoreLine(nil, 9)),
+				jurorTabs("Cleo", contestants, scoreLine(nil, 1), scoreLine(nil, 10)),
+			}},
+			// Cleo is weighted with 0% and left out, so both contestants sum to 3
+			order:    []string{"Clara", "David"},
+			totals:   []float64{3, 3},
+			complete: []bool{true, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			standings := computeStandings(test.comp, test.results)
+			if len(standings.Results) != len(test.order) {
+				t.Fatalf("got %d results, want %d", len(standings.Results), len(test.order))
+			}
+			for i, result := range standings.Results {
+				if result.Contestant != test.order[i] {
+					t.Errorf("place %d: got %s, want %s", i+1, result.Contestant, test.order[i])
+				}
+				if !sameScore(result.Total, test.totals[i]) {
+					t.Errorf("%s: total = %.4f, want %.4f", result.Contestant, result.Total, test.totals[i])
+				}
+				if result.Complete != test.complete[i] {
+					t.Errorf("%s: complete = %v, want %v", result.Contestant, result.Complete, test.complete[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRankResultsComparesShownTotals(t *testing.T) {
+	// 8.004 and 8.006 are within the tolerance of each other, 8.001 and 8.006 are not. Rounded
+	// as shown, only 8.004 and 8.001 are equal.
+	results := []*ContestantResult{
+		{Contestant: "Clara", Total: 8.004},
+		{Contestant: "David", Total: 8.001},
+		{Contestant: "Eva", Total: 8.006},
+	}
+	rankResults(results, false, newTieBreaker(nil, nil, nil, results))
+
+	order := []string{"Eva", "Clara", "David"}
+	ranks := []int{1, 2, 2}
+	for i, result := range results {
+		if result.Contestant != order[i] || result.Rank != ranks[i] {
+			t.Errorf("place %d: got %s ranked %d, want %s ranked %d", i+1, result.Contestant, result.Rank, order[i], ranks[i])
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	createNewOption    = "[Create New]"  // fileSelect option starting a new competition
+	storeWatchInterval = 2 * time.Second // Polling interval when change notifications are unavailable
+)
+
+var competitionIDPattern = regexp.MustCompile(`^[a-z0-9]{8,32}$`)
+
+// store is the CompetitionStore used by the app, set up in initializeDataDir
+var store CompetitionStore
+
+// CompetitionStore persists competitions. IDs returned by List identify competitions in
+// the store. A competition may get a new ID when Load migrates it from an older schema,
+// in which case the returned Competition carries the new ID.
+type CompetitionStore interface {
+	List() ([]CompetitionInfo, error)
+	Load(id string) (Competition, error)
+	Save(comp Competition) error
+	Delete(id string) error
+	Rename(id, newName string) error
+	// Watch calls onChange whenever competitions are added, changed or removed,
+	// until the returned stop function is called.
+	Watch(onChange func()) (stop func(), err error)
+	// Location describes where the competitions are stored, for display
+	Location() string
+
+	// Documents are the presets, drafts and cached results kept next to the competitions,
+	// grouped by kind, so they move with the store. ReadDocument returns nil if there is
+	// no such document and DeleteDocument then does nothing.
+	ListDocuments(kind string) ([]StoredDocument, error)
+	ReadDocument(kind, id string) ([]byte, error)
+	WriteDocument(kind, id string, data []byte) error
+	DeleteDocument(kind, id string) error
+}
+
+// HistoryStore is implemented by stores that keep earlier versions of each competition
+type HistoryStore interface {
+	History(id string) ([]HistoryVersion, error)
+	LoadVersion(id string, version HistoryVersion) (Competition, error)
+}
+
+// CompetitionInfo describes a stored competition without loading all of it
+type CompetitionInfo struct {
+	ID       string
+	Name     string
+	Modified time.Time
+}
+
+// StoredDocument is a document kept in the store next to the competitions
+type StoredDocument struct {
+	ID       string
+	Data     []byte
+	Modified time.Time
+}
+
+// documentKinds are the kinds of documents a store keeps, each in a folder of that name
+var documentKinds = map[string]bool{
+	presetsDir:      true,
+	draftsDir:       true,
+	resultsCacheDir: true,
+}
+
+// competitionDocumentKinds are the kinds of documents stored under the ID of the competition
+// they belong to, which are removed with it
+var competitionDocumentKinds = []string{draftsDir, resultsCacheDir}
+
+// validateDocument rejects unknown document kinds and IDs that are unsafe as file names
+func validateDocument(kind, id string) error {
+	if !documentKinds[kind] {
+		return fmt.Errorf("unknown document kind '%s'", kind)
+	}
+	if !isValidCompetitionID(id) {
+		return fmt.Errorf("invalid %s ID '%s'", kind, id)
+	}
+	return nil
+}
+
+// newCompetitionID returns a random identifier used as the competition's file name
+func newCompetitionID() string {
+	var buf [8]byte
+	if _, err := crand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// isValidCompetitionID reports whether id is safe to use as a file name
+func isValidCompetitionID(id string) bool {
+	return competitionIDPattern.MatchString(id)
+}
+
+// competitionFileName returns the file name a competition is stored under
+func competitionFileName(id string) string {
+	return id + ".json"
+}
+
+// sanitizeFileName turns a competition name into a string that is safe to use as a file name
+func sanitizeFileName(name string) string {
+	var builder strings.Builder
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case r == '/' || r == '\\' || r == ':' || r == '*' || r == '?' || r == '"' || r == '<' || r == '>' || r == '|':
+			builder.WriteRune('_')
+		case r < 32:
+			continue
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	sanitized := strings.Trim(builder.String(), ". ")
+	if sanitized == "" {
+		return "competition"
+	}
+	return sanitized
+}
+
+// encodeCompetition serializes a competition with the current schema version
+func encodeCompetition(comp Competition) ([]byte, error) {
+	comp.SchemaVersion = currentSchemaVersion
+	data, err := json.MarshalIndent(comp, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize competition: %w", err)
+	}
+	return data, nil
+}
+
+// competitionName returns the name stored in a competition document, or fallback
+func competitionName(data []byte, fallback string) string {
+	var header struct {
+		Name string `json:"name"`
+	}
+	if json.Unmarshal(data, &header) == nil && strings.TrimSpace(header.Name) != "" {
+		return header.Name
+	}
+	return fallback
+}
+
+// sortCompetitionInfos sorts competitions by name
+func sortCompetitionInfos(infos []CompetitionInfo) {
+	sort.SliceStable(infos, func(i, j int) bool {
+		return strings.ToLower(infos[i].Name) < strings.ToLower(infos[j].Name)
+	})
+}
+
+// competitionOptions returns the names shown in fileSelect and maps each of them to its ID.
+// Competitions sharing a name are numbered so every option stays unique.
+func competitionOptions(infos []CompetitionInfo) ([]string, map[string]string) {
+	options := []string{}
+	ids := make(map[string]string)
+	for _, info := range infos {
+		option := info.Name
+		for n := 2; ids[option] != "" || option == createNewOption; n++ {
+			option = fmt.Sprintf("%s (%d)", info.Name, n)
+		}
+		options = append(options, option)
+		ids[option] = info.ID
+	}
+	return options, ids
+}
+
+// writeFileAtomic writes data to a temporary file next to filePath and renames it into place,
+// so a crash during the write never leaves a partially written file behind.
+func writeFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), ".tmp-"+filepath.Base(filePath)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath) // No-op once the rename succeeded
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to flush temporary file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Chmod(tempPath, perm); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := os.Rename(tempPath, filePath); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+	return nil
+}
+
+// storedModified returns when the stored competition was last modified, or the zero time
+// if it is not stored
+func storedModified(id string) time.Time {
+	infos, err := store.List()
+	if err != nil {
+		return time.Time{}
+	}
+	for _, info := range infos {
+		if info.ID == id {
+			return info.Modified
+		}
+	}
+	return time.Time{}
+}
+
+// pollWatch calls onChange whenever the result of list changes. It is used by stores
+// that cannot be notified of changes.
+func pollWatch(list func() ([]CompetitionInfo, error), interval time.Duration, onChange func()) func() {
+	signature := func() string {
+		infos, err := list()
+		if err != nil {
+			return "error: " + err.Error()
+		}
+		var builder strings.Builder
+		for _, info := range infos {
+			fmt.Fprintf(&builder, "%s|%s|%d\n", info.ID, info.Name, info.Modified.UnixNano())
+		}
+		return builder.String()
+	}
+
+	last := signature()
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if current := signature(); current != last {
+					last = current
+					onChange()
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// storeFactories creates each CompetitionStore implementation in a fresh temporary location
+var storeFactories = []struct {
+	name string
+	open func(t *testing.T) CompetitionStore
+}{
+	{"directory", func(t *testing.T) CompetitionStore {
+		return newDirStore(filepath.Join(t.TempDir(), competitionsDir))
+	}},
+	{"archive", func(t *testing.T) CompetitionStore {
+		return newArchiveStore(filepath.Join(t.TempDir(), "competitions.zip"))
+	}},
+}
+
+func testCompetition(name string) Competition {
+	return Competition{
+		ID:   newCompetitionID(),
+		Name: name,
+		Jury: []*Juror{
+			{Name: "Anna", Weight: 60},
+			{Name: "Ben", Weight: 40},
+		},
+		Contestants: []*Contestant{
+			{Name: "Clara", Team: "North"},
+		},
+	}
+}
+
+func TestCompetitionStore(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store CompetitionStore)
+	}{
+		{"empty store lists nothing", func(t *testing.T, store CompetitionStore) {
+			infos, err := store.List()
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(infos) != 0 {
+				t.Errorf("List returned %d competitions, want 0", len(infos))
+			}
+		}},
+		{"save and load round trip", func(t *testing.T, store CompetitionStore) {
+			comp := testCompetition("Winter Cup")
+			if err := store.Save(comp); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			loaded, err := store.Load(comp.ID)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if loaded.SchemaVersion != currentSchemaVersion {
+				t.Errorf("SchemaVersion = %d, want %d", loaded.SchemaVersion, currentSchemaVersion)
+			}
+			if changes := diffCompetitions(comp, loaded); len(changes) != 0 {
+				t.Errorf("loaded competition differs: %v", changes)
+			}
+		}},
+		{"list is sorted by name", func(t *testing.T, store CompetitionStore) {
+			for _, name := range []string{"zeta", "Alpha", "beta"} {
+				if err := store.Save(testCompetition(name)); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+			}
+			infos, err := store.List()
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			want := []string{"Alpha", "beta", "zeta"}
+			if len(infos) != len(want) {
+				t.Fatalf("List returned %d competitions, want %d", len(infos), len(want))
+			}
+			for i, info := range infos {
+				if info.Name != want[i] {
+					t.Errorf("infos[%d].Name = %q, want %q", i, info.Name, want[i])
+				}
+			}
+		}},
+		{"rename keeps the ID", func(t *testing.T, store CompetitionStore) {
+			comp := testCompetition("Old Name")
+			if err := store.Save(comp); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			if err := store.Rename(comp.ID, "New Name"); err != nil {
+				t.Fatalf("Rename: %v", err)
+			}
+			infos, err := store.List()
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(infos) != 1 || infos[0].ID != comp.ID || infos[0].Name != "New Name" {
+				t.Errorf("List = %+v, want one competition %s named 'New Name'", infos, comp.ID)
+			}
+		}},
+		{"delete removes the competition", func(t *testing.T, store CompetitionStore) {
+			comp := testCompetition("Summer Cup")
+			if err := store.Save(comp); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			comp.Name = "Summer Cup 2"
+			if err := store.Save(comp); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			for _, kind := range competitionDocumentKinds {
+				if err := store.WriteDocument(kind, comp.ID, []byte(`{}`)); err != nil {
+					t.Fatalf("WriteDocument(%s): %v", kind, err)
+				}
+			}
+			if err := store.Delete(comp.ID); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if _, err := store.Load(comp.ID); err == nil {
+				t.Error("Load succeeded after Delete")
+			}
+			if history, ok := store.(HistoryStore); ok {
+				if versions, _ := history.History(comp.ID); len(versions) != 0 {
+					t.Errorf("History returned %d versions after Delete, want 0", len(versions))
+				}
+			}
+			for _, kind := range competitionDocumentKinds {
+				if data, _ := store.ReadDocument(kind, comp.ID); data != nil {
+					t.Errorf("%s document still exists after Delete", kind)
+				}
+			}
+			if err := store.Delete(comp.ID); err == nil {
+				t.Error("second Delete succeeded")
+			}
+		}},
+		{"invalid IDs are rejected", func(t *testing.T, store CompetitionStore) {
+			for _, id := range []string{"", "../outside", "UPPERCASE1"} {
+				comp := testCompetition("Invalid")
+				comp.ID = id
+				if err := store.Save(comp); err == nil {
+					t.Errorf("Save with ID %q succeeded", id)
+				}
+			}
+		}},
+		{"missing competition fails to load", func(t *testing.T, store CompetitionStore) {
+			if _, err := store.Load(newCompetitionID()); err == nil {
+				t.Error("Load of a missing competition succeeded")
+			}
+		}},
+		{"saving keeps the previous version", func(t *testing.T, store CompetitionStore) {
+			history, ok := store.(HistoryStore)
+			if !ok {
+				t.Skip("store keeps no history")
+			}
+			comp := testCompetition("Spring Cup")
+			if err := store.Save(comp); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			time.Sleep(1100 * time.Millisecond) // Versions are named after their modification time
+			comp.Contestants = append(comp.Contestants, &Contestant{Name: "David"})
+			if err := store.Save(comp); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			versions, err := history.History(comp.ID)
+			if err != nil {
+				t.Fatalf("History: %v", err)
+			}
+			if len(versions) != 1 {
+				t.Fatalf("History returned %d versions, want 1", len(versions))
+			}
+			previous, err := history.LoadVersion(comp.ID, versions[0])
+			if err != nil {
+				t.Fatalf("LoadVersion: %v", err)
+			}
+			if len(previous.Contestants) != 1 {
+				t.Errorf("previous version has %d contestants, want 1", len(previous.Contestants))
+			}
+		}},
+		{"versions saved at the same time are all kept", func(t *testing.T, store CompetitionStore) {
+			history, ok := store.(HistoryStore)
+			if !ok {
+				t.Skip("store keeps no history")
+			}
+			comp := testCompetition("Autumn Cup")
+			for _, name := range []string{"Autumn Cup 1", "Autumn Cup 2", "Autumn Cup 3"} {
+				comp.Name = name
+				if err := store.Save(comp); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+			}
+
+			versions, err := history.History(comp.ID)
+			if err != nil {
+				t.Fatalf("History: %v", err)
+			}
+			if len(versions) != 2 {
+				t.Fatalf("History returned %d versions, want 2", len(versions))
+			}
+			for i, want := range []string{"Autumn Cup 2", "Autumn Cup 1"} {
+				version, err := history.LoadVersion(comp.ID, versions[i])
+				if err != nil {
+					t.Fatalf("LoadVersion: %v", err)
+				}
+				if version.Name != want {
+					t.Errorf("version %d is named '%s', want '%s'", i+1, version.Name, want)
+				}
+			}
+		}},
+		{"documents round trip", func(t *testing.T, store CompetitionStore) {
+			id := newCompetitionID()
+			if data, err := store.ReadDocument(presetsDir, id); err != nil || data != nil {
+				t.Fatalf("ReadDocument of a missing document = %q, %v, want nil, nil", data, err)
+			}
+			if err := store.WriteDocument(presetsDir, id, []byte(`{"name": "Club Preset"}`)); err != nil {
+				t.Fatalf("WriteDocument: %v", err)
+			}
+			documents, err := store.ListDocuments(presetsDir)
+			if err != nil {
+				t.Fatalf("ListDocuments: %v", err)
+			}
+			if len(documents) != 1 || documents[0].ID != id || competitionName(documents[0].Data, "") != "Club Preset" {
+				t.Errorf("ListDocuments = %+v, want the preset %s", documents, id)
+			}
+			if others, _ := store.ListDocuments(draftsDir); len(others) != 0 {
+				t.Errorf("ListDocuments(drafts) returned %d documents, want 0", len(others))
+			}
+			if infos, _ := store.List(); len(infos) != 0 {
+				t.Errorf("List returned %d competitions, want the preset not to show up", len(infos))
+			}
+			if err := store.DeleteDocument(presetsDir, id); err != nil {
+				t.Fatalf("DeleteDocument: %v", err)
+			}
+			if data, _ := store.ReadDocument(presetsDir, id); data != nil {
+				t.Error("document still exists after DeleteDocument")
+			}
+			if err := store.DeleteDocument(presetsDir, id); err != nil {
+				t.Errorf("DeleteDocument of a missing document: %v", err)
+			}
+			if err := store.WriteDocument("../outside", id, nil); err == nil {
+				t.Error("WriteDocument with an unknown kind succeeded")
+			}
+		}},
+		{"watch reports changes", func(t *testing.T, store CompetitionStore) {
+			changed := make(chan struct{}, 1)
+			stop, err := store.Watch(func() {
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			})
+			if err != nil {
+				t.Fatalf("Watch: %v", err)
+			}
+			defer stop()
+
+			if err := store.Save(testCompetition("Watched")); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			select {
+			case <-changed:
+			case <-time.After(3 * storeWatchInterval):
+				t.Error("no change reported after Save")
+			}
+		}},
+	}
+
+	for _, factory := range storeFactories {
+		for _, test := range tests {
+			t.Run(factory.name+"/"+test.name, func(t *testing.T) {
+				test.run(t, factory.open(t))
+			})
+		}
+	}
+}
+
+func TestDirStoreMigratesLegacyFiles(t *testing.T) {
+	dir := t.TempDir()
+	legacy := `{"name": "Legacy Cup", "source_sheet_id": "sheet", "jury": null, "contestants": [{"name": "Eva"}]}`
+	if err := os.WriteFile(filepath.Join(dir, "Legacy Cup.json"), []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := newDirStore(dir)
+
+	infos, err := store.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Name != "Legacy Cup" {
+		t.Fatalf("List = %+v, want the legacy competition", infos)
+	}
+
+	comp, err := store.Load(infos[0].ID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !isValidCompetitionID(comp.ID) {
+		t.Errorf("migrated competition has invalid ID %q", comp.ID)
+	}
+	if comp.Jury == nil || len(comp.Contestants) != 1 {
+		t.Errorf("migrated competition = %+v", comp)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Legacy Cup.json")); !os.IsNotExist(err) {
+		t.Error("legacy file still exists after migration")
+	}
+	if _, err := os.Stat(filepath.Join(dir, competitionFileName(comp.ID))); err != nil {
+		t.Errorf("migrated file missing: %v", err)
+	}
+	backups, _ := os.ReadDir(filepath.Join(dir, backupsDir))
+	if len(backups) != 1 {
+		t.Errorf("found %d backups, want 1", len(backups))
+	}
+}
+
+func TestArchiveStoreKeepsEverythingInOneFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "portable.zip")
+	store := newArchiveStore(file)
+	comp := testCompetition("Portable Cup")
+	if err := store.Save(comp); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "portable.zip" {
+		t.Errorf("directory contains %d entries, want only the archive", len(entries))
+	}
+
+	// A second store on the same file sees the competition, as after copying it elsewhere
+	loaded, err := newArchiveStore(file).Load(comp.ID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.Name != comp.Name {
+		t.Errorf("Name = %q, want %q", loaded.Name, comp.Name)
+	}
+}
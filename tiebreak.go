@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Tie-break rule kinds
+const (
+	tieBreakCriterion = "criterion"  // Higher jury score in a named criterion
+	tieBreakHeadJuror = "head_juror" // Higher total of the head juror
+	tieBreakCountBack = "count_back" // More first places, then more first and second places, ...
+	tieBreakShared    = "shared"     // The contestants share the placing
+)
+
+// tieBreakKinds lists the rule kinds with their display names, in menu order
+var tieBreakKinds = []struct {
+	Kind, Name string
+}{
+	{tieBreakCriterion, "Higher score in a criterion"},
+	{tieBreakHeadJuror, "Higher score of the head juror"},
+	{tieBreakCountBack, "Count-back of first places"},
+	{tieBreakShared, "Shared placing"},
+}
+
+// TieBreakRule settles a tie between contestants with the same total. Rules are applied
+// in order until the tie is broken.
+type TieBreakRule struct {
+	Kind      string `json:"kind"`
+	Criterion string `json:"criterion,omitempty"` // Criterion compared by tieBreakCriterion
+	Juror     string `json:"juror,omitempty"`     // Head juror compared by tieBreakHeadJuror
+}
+
+// String describes the rule for the results and exports
+func (r TieBreakRule) String() string {
+	switch r.Kind {
+	case tieBreakCriterion:
+		return fmt.Sprintf("Higher score in %s", r.Criterion)
+	case tieBreakHeadJuror:
+		return fmt.Sprintf("Higher score of head juror %s", r.Juror)
+	case tieBreakCountBack:
+		return "Count-back of first places"
+	case tieBreakShared:
+		return "Shared placing"
+	}
+	return r.Kind
+}
+
+// validateTieBreaks checks that the tie-break rules refer to existing criteria and jurors
+func validateTieBreaks(comp Competition) error {
+	for i, rule := range comp.TieBreaks {
+		switch rule.Kind {
+		case tieBreakCriterion:
+			found := false
+			for _, criterion := range comp.Criteria {
+				found = found || strings.EqualFold(strings.TrimSpace(criterion.Name), strings.TrimSpace(rule.Criterion))
+			}
+			if !found {
+				return fmt.Errorf("Tie-break rule #%d refers to the unknown criterion '%s'.", i+1, rule.Criterion)
+			}
+		case tieBreakHeadJuror:
+			found := false
+			for _, juror := range comp.Jury {
+				found = found || strings.EqualFold(strings.TrimSpace(juror.Name), strings.TrimSpace(rule.Juror))
+			}
+			if !found {
+				return fmt.Errorf("Tie-break rule #%d refers to the unknown juror '%s'.", i+1, rule.Juror)
+			}
+		case tieBreakCountBack, tieBreakShared:
+		default:
+			return fmt.Errorf("Tie-break rule #%d has the unknown kind '%s'.", i+1, rule.Kind)
+		}
+	}
+	return nil
+}
+
+// tieBreaker ranks contestants with equal totals
+type tieBreaker struct {
+	rules      []TieBreakRule
+	criteria   []string
+	jurors     []string
+	placements map[*ContestantResult][]int // Number of jurors placing the contestant 1st, 2nd, ...
+}
+
+func newTieBreaker(rules []TieBreakRule, criteria, jurors []string, results []*ContestantResult) *tieBreaker {
+	return &tieBreaker{rules: rules, criteria: criteria, jurors: jurors, placements: jurorPlacements(results, len(jurors))}
+}
+
+// jurorPlacements counts how often each contestant was placed 1st, 2nd, ... by a juror.
+// Contestants with the same juror total share the juror's placing.
+func jurorPlacements(results []*ContestantResult, jurorCount int) map[*ContestantResult][]int {
+	placements := make(map[*ContestantResult][]int)
+	for _, result := range results {
+		placements[result] = make([]int, len(results))
+	}
+	for j := 0; j < jurorCount; j++ {
+		for _, result := range results {
+			if j >= len(result.JurorTotals) || result.JurorTotals[j] == nil {
+				continue
+			}
+			place := 0
+			for _, other := range results {
+				if j < len(other.JurorTotals) && other.JurorTotals[j] != nil && roundScore(*other.JurorTotals[j]) > roundScore(*result.JurorTotals[j]) {
+					place++
+				}
+			}
+			placements[result][place]++
+		}
+	}
+	return placements
+}
+
+// key returns the values a rule compares, most significant first; higher is better
+func (t *tieBreaker) key(rule TieBreakRule, result *ContestantResult) []float64 {
+	switch rule.Kind {
+	case tieBreakCriterion:
+		for c, name := range t.criteria {
+			if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(rule.Criterion)) && c < len(result.CriterionScores) {
+				return []float64{result.CriterionScores[c]}
+			}
+		}
+	case tieBreakHeadJuror:
+		for j, name := range t.jurors {
+			if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(rule.Juror)) && j < len(result.JurorTotals) && result.JurorTotals[j] != nil {
+				return []float64{*result.JurorTotals[j]}
+			}
+		}
+		return []float64{math.Inf(-1)}
+	case tieBreakCountBack:
+		key := make([]float64, len(t.placements[result]))
+		sum := 0
+		for place, count := range t.placements[result] {
+			sum += count
+			key[place] = float64(sum)
+		}
+		return key
+	}
+	return nil
+}
+
+// compareKeys compares two keys and returns 1 if a is better, -1 if b is better and 0 if they
+// are equal. Values are compared as shown, rounded to two decimals (see rankResults).
+func compareKeys(a, b []float64) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, y := roundScore(a[i]), roundScore(b[i])
+		if x == y {
+			continue
+		}
+		if x > y {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// resolve orders a group of tied contestants and returns the groups that still share a
+// placing, best first. TieBreak is set to the rule that decided each contestant's placing.
+func (t *tieBreaker) resolve(group []*ContestantResult, ruleIndex int) [][]*ContestantResult {
+	if len(group) < 2 {
+		return [][]*ContestantResult{group}
+	}
+	if ruleIndex >= len(t.rules) || t.rules[ruleIndex].Kind == tieBreakShared {
+		for _, result := range group {
+			result.TieBreak = TieBreakRule{Kind: tieBreakShared}.String()
+		}
+		return [][]*ContestantResult{group}
+	}
+
+	rule := t.rules[ruleIndex]
+	keys := make(map[*ContestantResult][]float64)
+	for _, result := range group {
+		keys[result] = t.key(rule, result)
+	}
+	sorted := append([]*ContestantResult(nil), group...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return compareKeys(keys[sorted[a]], keys[sorted[b]]) > 0
+	})
+
+	var partitions [][]*ContestantResult
+	for i, result := range sorted {
+		if i > 0 && compareKeys(keys[result], keys[sorted[i-1]]) == 0 {
+			partitions[len(partitions)-1] = append(partitions[len(partitions)-1], result)
+		} else {
+			partitions = append(partitions, []*ContestantResult{result})
+		}
+	}
+	if len(partitions) == 1 {
+		return t.resolve(group, ruleIndex+1)
+	}
+
+	var resolved [][]*ContestantResult
+	for _, partition := range partitions {
+		for _, result := range partition {
+			result.TieBreak = rule.String()
+		}
+		resolved = append(resolved, t.resolve(partition, ruleIndex+1)...)
+	}
+	return resolved
+}
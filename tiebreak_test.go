@@ -0,0 +1,107 @@
+package main
+
+import "testing"
+
+// tiedResult returns a result with the given total, juror totals and criterion scores
+func tiedResult(name string, total float64, jurorTotals []float64, criterionScores ...float64) *ContestantResult {
+	result := &ContestantResult{Contestant: name, Total: total, CriterionScores: criterionScores}
+	for _, value := range jurorTotals {
+		value := value
+		result.JurorTotals = append(result.JurorTotals, &value)
+	}
+	return result
+}
+
+func TestRankResultsTieBreaks(t *testing.T) {
+	jurors := []string{"Anna", "Ben", "Cleo"}
+	criteria := []string{"Technique", "Show"}
+
+	// Xena and Yuri share a total of 8. Both are placed first twice (Cleo places them
+	// equal), but only Xena is placed second by the remaining juror.
+	results := func() []*ContestantResult {
+		return []*ContestantResult{
+			tiedResult("Zoe", 6, []float64{9, 5, 4}, 6, 6),
+			tiedResult("Yuri", 8, []float64{7, 9, 8}, 9, 7),
+			tiedResult("Xena", 8, []float64{10, 6, 8}, 8, 8),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		rules    []TieBreakRule
+		order    []string
+		ranks    []int
+		tieBreak string // Rule recorded for the tied contestants
+	}{
+		{
+			name:     "no rules share the placing",
+			order:    []string{"Yuri", "Xena", "Zoe"},
+			ranks:    []int{1, 1, 3},
+			tieBreak: "Shared placing",
+		},
+		{
+			name:     "count-back goes on to second places",
+			rules:    []TieBreakRule{{Kind: tieBreakCountBack}},
+			order:    []string{"Xena", "Yuri", "Zoe"},
+			ranks:    []int{1, 2, 3},
+			tieBreak: "Count-back of first places",
+		},
+		{
+			name:     "criterion decides before count-back",
+			rules:    []TieBreakRule{{Kind: tieBreakCriterion, Criterion: "technique"}, {Kind: tieBreakCountBack}},
+			order:    []string{"Yuri", "Xena", "Zoe"},
+			ranks:    []int{1, 2, 3},
+			tieBreak: "Higher score in technique",
+		},
+		{
+			name:     "equal head juror totals fall through to the next rule",
+			rules:    []TieBreakRule{{Kind: tieBreakHeadJuror, Juror: "Cleo"}, {Kind: tieBreakCountBack}},
+			order:    []string{"Xena", "Yuri", "Zoe"},
+			ranks:    []int{1, 2, 3},
+			tieBreak: "Count-back of first places",
+		},
+		{
+			name:     "head juror",
+			rules:    []TieBreakRule{{Kind: tieBreakHeadJuror, Juror: "Ben"}},
+			order:    []string{"Yuri", "Xena", "Zoe"},
+			ranks:    []int{1, 2, 3},
+			tieBreak: "Higher score of head juror Ben",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ranked := results()
+			rankResults(ranked, false, newTieBreaker(test.rules, criteria, jurors, ranked))
+			for i, result := range ranked {
+				if result.Contestant != test.order[i] || result.Rank != test.ranks[i] {
+					t.Errorf("place %d: got %s ranked %d, want %s ranked %d", i+1, result.Contestant, result.Rank, test.order[i], test.ranks[i])
+				}
+				if result.Total == 8 && result.TieBreak != test.tieBreak {
+					t.Errorf("%s: tie-break = %q, want %q", result.Contestant, result.TieBreak, test.tieBreak)
+				}
+			}
+		})
+	}
+}
+
+func TestJurorPlacements(t *testing.T) {
+	// Equal juror totals share the better placing
+	a := tiedResult("A", 0, []float64{9, 7})
+	b := tiedResult("B", 0, []float64{9, 8})
+	c := tiedResult("C", 0, []float64{5, 8})
+	placements := jurorPlacements([]*ContestantResult{a, b, c}, 2)
+
+	want := map[*ContestantResult][]int{
+		a: {1, 0, 1},
+		b: {2, 0, 0},
+		c: {1, 0, 1},
+	}
+	for result, counts := range want {
+		for place, count := range counts {
+			if placements[result][place] != count {
+				t.Errorf("%s placed %d: %d time(s), want %d", result.Contestant, place+1, placements[result][place], count)
+			}
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+const undoLimit = 200 // Number of commands kept in the undo history
+
+// Command is an undoable edit of the open competition
+type Command interface {
+	Name() string
+	Do()
+	Undo()
+}
+
+// funcCommand is a Command made of two functions
+type funcCommand struct {
+	name     string
+	do, undo func()
+}
+
+func newCommand(name string, do, undo func()) Command {
+	return &funcCommand{name: name, do: do, undo: undo}
+}
+
+func (c *funcCommand) Name() string { return c.name }
+func (c *funcCommand) Do()          { c.do() }
+func (c *funcCommand) Undo()        { c.undo() }
+
+// fieldEdit changes a single value. Consecutive typing in the same field is merged into
+// one command, so undo restores the field as it was before the user started typing.
+type fieldEdit struct {
+	key      string // Identifies the edited field, e.g. per juror and column
+	name     string
+	oldValue string
+	newValue string
+	typing   bool // Merge with the previous edit of the same field
+	apply    func(value string)
+}
+
+func (e *fieldEdit) Name() string { return e.name }
+func (e *fieldEdit) Do()          { e.apply(e.newValue) }
+func (e *fieldEdit) Undo()        { e.apply(e.oldValue) }
+
+// UndoHistory keeps the commands that can be undone and redone
+type UndoHistory struct {
+	mutex     sync.Mutex
+	undo      []Command
+	redo      []Command
+	sealed    bool // The newest command must not absorb further typing
+	suspended int  // Changes made while suspended are not recorded
+	epoch     int  // Number of times the history was cleared
+
+	OnChanged func() // Called after the history changed, e.g. to update menu items
+}
+
+func newUndoHistory() *UndoHistory {
+	return &UndoHistory{}
+}
+
+// Execute applies a command and adds it to the history
+func (h *UndoHistory) Execute(cmd Command) {
+	h.mutex.Lock()
+	h.suspended++
+	h.mutex.Unlock()
+	cmd.Do()
+	h.mutex.Lock()
+	h.suspended--
+	h.mutex.Unlock()
+	h.Record(cmd)
+}
+
+// Record adds a command whose change was already applied, e.g. by typing into an entry
+func (h *UndoHistory) Record(cmd Command) {
+	h.mutex.Lock()
+	if h.suspended > 0 {
+		h.mutex.Unlock()
+		return
+	}
+
+	merged := false
+	if edit, ok := cmd.(*fieldEdit); ok && edit.typing && !h.sealed && len(h.undo) > 0 {
+		if last, ok := h.undo[len(h.undo)-1].(*fieldEdit); ok && last.typing && last.key == edit.key {
+			last.newValue = edit.newValue
+			if last.newValue == last.oldValue {
+				h.undo = h.undo[:len(h.undo)-1] // Typed back to the original value
+			}
+			merged = true
+		}
+	}
+	if !merged {
+		if edit, ok := cmd.(*fieldEdit); ok && edit.oldValue == edit.newValue {
+			h.mutex.Unlock()
+			return
+		}
+		h.undo = append(h.undo, cmd)
+		if len(h.undo) > undoLimit {
+			h.undo = h.undo[len(h.undo)-undoLimit:]
+		}
+	}
+	h.redo = nil
+	h.sealed = false
+	h.mutex.Unlock()
+	h.changed()
+}
+
+// Undo reverts the newest command
+func (h *UndoHistory) Undo() {
+	h.mutex.Lock()
+	if len(h.undo) == 0 {
+		h.mutex.Unlock()
+		return
+	}
+	cmd := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.suspended++
+	h.mutex.Unlock()
+
+	cmd.Undo()
+
+	h.mutex.Lock()
+	h.suspended--
+	h.redo = append(h.redo, cmd)
+	h.sealed = true
+	h.mutex.Unlock()
+	h.changed()
+}
+
+// Redo applies the newest undone command again
+func (h *UndoHistory) Redo() {
+	h.mutex.Lock()
+	if len(h.redo) == 0 {
+		h.mutex.Unlock()
+		return
+	}
+	cmd := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.suspended++
+	h.mutex.Unlock()
+
+	cmd.Do()
+
+	h.mutex.Lock()
+	h.suspended--
+	h.undo = append(h.undo, cmd)
+	h.sealed = true
+	h.mutex.Unlock()
+	h.changed()
+}
+
+// Clear drops all commands, e.g. after another competition was loaded
+func (h *UndoHistory) Clear() {
+	h.mutex.Lock()
+	h.undo = nil
+	h.redo = nil
+	h.epoch++
+	h.mutex.Unlock()
+	h.changed()
+}
+
+// Epoch changes whenever the history is cleared, so edits captured before can be told to
+// belong to a competition that is no longer open
+func (h *UndoHistory) Epoch() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.epoch
+}
+
+// Suspend runs change without recording the edits it makes
+func (h *UndoHistory) Suspend(change func()) {
+	h.mutex.Lock()
+	h.suspended++
+	h.mutex.Unlock()
+	defer func() {
+		h.mutex.Lock()
+		h.suspended--
+		h.mutex.Unlock()
+	}()
+	change()
+}
+
+// UndoName returns the name of the command Undo would revert, or "" if there is none
+func (h *UndoHistory) UndoName() string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if len(h.undo) == 0 {
+		return ""
+	}
+	return h.undo[len(h.undo)-1].Name()
+}
+
+// RedoName returns the name of the command Redo would apply, or "" if there is none
+func (h *UndoHistory) RedoName() string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if len(h.redo) == 0 {
+		return ""
+	}
+	return h.redo[len(h.redo)-1].Name()
+}
+
+func (h *UndoHistory) changed() {
+	if h.OnChanged != nil {
+		h.OnChanged()
+	}
+}
+
+// replaceListCommand returns the command that replaces a whole list, e.g. by an import or a
+// draw. Undo puts back the items the list held when the command was created.
+func replaceListCommand[T any](name string, list *[]T, mutex *sync.RWMutex, replacement []T, refresh func()) Command {
+	mutex.RLock()
+	previous := append([]T(nil), *list...)
+	mutex.RUnlock()
+	set := func(items []T) {
+		mutex.Lock()
+		*list = append([]T(nil), items...)
+		mutex.Unlock()
+		refresh()
+	}
+	return newCommand(name, func() {
+		set(replacement)
+	}, func() {
+		set(previous)
+	})
+}
+
+// recordWindowEdits records the changes made in an editor window as one command when the
+// window is closed. capture returns a copy of the state the window edits and restore puts
+// such a copy back. Nothing is recorded if the state did not change or another competition
+// was opened in the meantime.
+func recordWindowEdits[T any](history *UndoHistory, window fyne.Window, name string, capture func() T, restore func(T)) {
+	before, epoch := capture(), history.Epoch()
+	window.SetOnClosed(func() {
+		after := capture()
+		if history.Epoch() != epoch || reflect.DeepEqual(before, after) {
+			return
+		}
+		history.Record(newCommand(name, func() {
+			restore(after)
+		}, func() {
+			restore(before)
+		}))
+	})
+}
+
+// redoShortcut is Ctrl+Shift+Z, or Cmd+Shift+Z on macOS
+var redoShortcut = &desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift}
+
+// isUndoShortcut reports whether a shortcut triggers undo or redo of the competition history
+func isUndoShortcut(shortcut fyne.Shortcut) (undo bool, redo bool) {
+	switch s := shortcut.(type) {
+	case *fyne.ShortcutUndo:
+		return true, false
+	case *fyne.ShortcutRedo:
+		return false, true
+	case *desktop.CustomShortcut:
+		return false, s.ShortcutName() == redoShortcut.ShortcutName()
+	}
+	return false, false
+}
+
+// historyEntry is an Entry whose undo and redo shortcuts act on the competition history
+// instead of only the entry's own text
+type historyEntry struct {
+	widget.Entry
+	history *UndoHistory
+}
+
+func newHistoryEntry(history *UndoHistory) *historyEntry {
+	entry := &historyEntry{history: history}
+	entry.ExtendBaseWidget(entry)
+	return entry
+}
+
+func (e *historyEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	switch undo, redo := isUndoShortcut(shortcut); {
+	case undo:
+		e.history.Undo()
+	case redo:
+		e.history.Redo()
+	default:
+		e.Entry.TypedShortcut(shortcut)
+	}
+}
+
+// removeItem returns items without item, keeping the order of the others
+func removeItem[T comparable](items []T, item T) []T {
+	for i, candidate := range items {
+		if candidate == item {
+			return append(items[:i:i], items[i+1:]...)
+		}
+	}
+	return items
+}
+
+// insertItem returns items with item inserted at index, or appended if index is past the end
+func insertItem[T any](items []T, index int, item T) []T {
+	if index > len(items) {
+		index = len(items)
+	}
+	result := append(items[:index:index], item)
+	return append(result, items[index:]...)
+}
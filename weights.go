@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Criterion is a scoring criterion with its share of the total score in percent
+type Criterion struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// normalizeWeights scales the weights so they add up to exactly 100. Rounding is
+// resolved with the largest remainder method, so the result always sums to 100.
+// If all weights are zero, the 100 points are split evenly.
+func normalizeWeights(weights []int) []int {
+	normalized := make([]int, len(weights))
+	if len(weights) == 0 {
+		return normalized
+	}
+
+	total := 0
+	for _, weight := range weights {
+		if weight > 0 {
+			total += weight
+		}
+	}
+
+	remainders := make([]float64, len(weights))
+	assigned := 0
+	for i, weight := range weights {
+		var exact float64
+		if total == 0 {
+			exact = 100 / float64(len(weights))
+		} else if weight > 0 {
+			exact = float64(weight) * 100 / float64(total)
+		}
+		normalized[i] = int(exact)
+		remainders[i] = exact - float64(normalized[i])
+		assigned += normalized[i]
+	}
+
+	// Hand out the points lost to rounding, largest remainder first
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for i := 0; assigned < 100; i++ {
+		normalized[order[i%len(order)]]++
+		assigned++
+	}
+	return normalized
+}
+
+// sumJurorWeights returns the total weight of the jury in percent
+func sumJurorWeights(jurors []*Juror) int {
+	total := 0
+	for _, juror := range jurors {
+		total += juror.Weight
+	}
+	return total
+}
+
+// sumCriterionWeights returns the total weight of the criteria in percent
+func sumCriterionWeights(criteria []*Criterion) int {
+	total := 0
+	for _, criterion := range criteria {
+		total += criterion.Weight
+	}
+	return total
+}
+
+// scoresCriterion reports whether a juror scores the named criterion.
+// Jurors without a criteria selection score all criteria.
+func (j *Juror) scoresCriterion(name string) bool {
+	if len(j.Criteria) == 0 {
+		return true
+	}
+	for _, criterion := range j.Criteria {
+		if strings.EqualFold(criterion, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveJurorWeights returns, for every juror and criterion, the share the juror's score
+// has in that criterion. The weights of the jurors scoring a criterion are rescaled so they
+// add up to 1, which keeps each criterion balanced when some jurors do not score it.
+func effectiveJurorWeights(jurors []*Juror, criteria []*Criterion) [][]float64 {
+	matrix := make([][]float64, len(jurors))
+	for j := range jurors {
+		matrix[j] = make([]float64, len(criteria))
+	}
+	for c, criterion := range criteria {
+		total := 0
+		for _, juror := range jurors {
+			if juror.scoresCriterion(criterion.Name) {
+				total += juror.Weight
+			}
+		}
+		if total == 0 {
+			continue
+		}
+		for j, juror := range jurors {
+			if juror.scoresCriterion(criterion.Name) {
+				matrix[j][c] = float64(juror.Weight) / float64(total)
+			}
+		}
+	}
+	return matrix
+}
+
+// effectiveJurorShares returns the share each juror has in a contestant's total. Without
+// criteria it is the juror's weight relative to the weight of the jury, so the shares add up
+// to 1 even if the weights do not add up to 100. With criteria it adds up the juror's
+// effective weight per criterion (see effectiveJurorWeights) times the criterion weight.
+// This is the weight written next to each juror row of the Overview.
+func effectiveJurorShares(jurors []*Juror, criteria []*Criterion) []float64 {
+	shares := make([]float64, len(jurors))
+	if len(criteria) == 0 {
+		if total := sumJurorWeights(jurors); total > 0 {
+			for j, juror := range jurors {
+				shares[j] = float64(juror.Weight) / float64(total)
+			}
+		}
+		return shares
+	}
+	matrix := effectiveJurorWeights(jurors, criteria)
+	for j := range jurors {
+		for c, criterion := range criteria {
+			shares[j] += float64(criterion.Weight) / 100 * matrix[j][c]
+		}
+	}
+	return shares
+}
+
+// validateWeights checks the juror and criterion weights of a competition
+func validateWeights(comp Competition) error {
+	if total := sumJurorWeights(comp.Jury); total != 100 {
+		return fmt.Errorf("The juror weights add up to %d%%, but must add up to 100%%. Use 'Normalize' to rescale them.", total)
+	}
+
+	if len(comp.Criteria) == 0 {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for i, criterion := range comp.Criteria {
+		name := strings.ToLower(strings.TrimSpace(criterion.Name))
+		if name == "" {
+			return fmt.Errorf("Criterion #%d has an empty name.", i+1)
+		}
+		if names[name] {
+			return fmt.Errorf("Criterion '%s' is defined more than once.", criterion.Name)
+		}
+		names[name] = true
+		if criterion.Weight < 0 || criterion.Weight > 100 {
+			return fmt.Errorf("Criterion '%s' has an invalid weight (%d). Must be between 0 and 100.", criterion.Name, criterion.Weight)
+		}
+	}
+	if total := sumCriterionWeights(comp.Criteria); total != 100 {
+		return fmt.Errorf("The criterion weights add up to %d%%, but must add up to 100%%.", total)
+	}
+
+	for _, juror := range comp.Jury {
+		for _, name := range juror.Criteria {
+			if !names[strings.ToLower(strings.TrimSpace(name))] {
+				return fmt.Errorf("Juror '%s' is assigned the unknown criterion '%s'.", juror.Name, name)
+			}
+		}
+	}
+
+	matrix := effectiveJurorWeights(comp.Jury, comp.Criteria)
+	for c, criterion := range comp.Criteria {
+		scored := false
+		for j := range comp.Jury {
+			if matrix[j][c] > 0 {
+				scored = true
+				break
+			}
+		}
+		if !scored && criterion.Weight > 0 {
+			return fmt.Errorf("Criterion '%s' is not scored by any juror with a weight above 0.", criterion.Name)
+		}
+	}
+	return nil
+}
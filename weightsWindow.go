@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Weights Editor Window Function
+func showWeightsEditor(myApp fyne.App, history *UndoHistory, current *Competition, jurors *[]*Juror) {
+	weightsWindow := myApp.NewWindow("Weights")
+	weightsWindow.Resize(fyne.NewSize(650, 550))
+
+	// The edits are undone together: the criteria and which of them each juror scores
+	type weightsState struct {
+		Criteria      []Criterion
+		JurorCriteria map[*Juror][]string
+	}
+	recordWindowEdits(history, weightsWindow, "Edit Criteria", func() weightsState {
+		state := weightsState{JurorCriteria: make(map[*Juror][]string)}
+		for _, criterion := range current.Criteria {
+			state.Criteria = append(state.Criteria, *criterion)
+		}
+		jurorsMutex.RLock()
+		defer jurorsMutex.RUnlock()
+		for _, juror := range *jurors {
+			state.JurorCriteria[juror] = append([]string(nil), juror.Criteria...)
+		}
+		return state
+	}, func(state weightsState) {
+		current.Criteria = nil
+		for _, criterion := range state.Criteria {
+			criterion := criterion
+			current.Criteria = append(current.Criteria, &criterion)
+		}
+		jurorsMutex.Lock()
+		defer jurorsMutex.Unlock()
+		for juror, criteria := range state.JurorCriteria {
+			juror.Criteria = append([]string(nil), criteria...)
+		}
+	})
+
+	// Live sum of the criterion weights
+	criteriaSumText := canvas.NewText("", color.Black)
+	criteriaSumText.TextSize = 12
+	updateCriteriaSum := func() {
+		updateWeightSumText(criteriaSumText, sumCriterionWeights(current.Criteria), len(current.Criteria) > 0)
+	}
+
+	criteriaRows := container.NewVBox()
+	assignmentRows := container.NewVBox()
+
+	// Juror assignments, one check group per juror
+	var rebuildAssignments func()
+	rebuildAssignments = func() {
+		names := []string{}
+		for _, criterion := range current.Criteria {
+			if name := strings.TrimSpace(criterion.Name); name != "" {
+				names = append(names, name)
+			}
+		}
+
+		jurorsMutex.RLock()
+		defer jurorsMutex.RUnlock()
+
+		assignmentRows.RemoveAll()
+		if len(names) == 0 {
+			assignmentRows.Add(widget.NewLabel("Define criteria above to assign them to jurors. Without criteria every juror scores everything."))
+		}
+		for _, juror := range *jurors {
+			if len(names) == 0 {
+				break
+			}
+			juror := juror
+
+			checks := widget.NewCheckGroup(names, nil)
+			checks.Horizontal = true
+			selected := []string{}
+			for _, name := range names {
+				if juror.scoresCriterion(name) {
+					selected = append(selected, name)
+				}
+			}
+			checks.SetSelected(selected)
+			checks.OnChanged = func(selected []string) {
+				jurorsMutex.Lock()
+				defer jurorsMutex.Unlock()
+				if len(selected) == len(names) {
+					juror.Criteria = nil // Scores all criteria
+				} else {
+					juror.Criteria = append([]string{}, selected...)
+				}
+			}
+
+			assignmentRows.Add(container.NewBorder(nil, nil,
+				widget.NewLabel(fmt.Sprintf("%s (%d%%)", juror.Name, juror.Weight)), nil,
+				checks,
+			))
+		}
+		assignmentRows.Refresh()
+	}
+
+	// Criteria rows with name, weight and a remove button
+	var rebuildCriteria func()
+	rebuildCriteria = func() {
+		criteriaRows.RemoveAll()
+		for i, criterion := range current.Criteria {
+			i, criterion := i, criterion
+
+			nameEntry := widget.NewEntry()
+			nameEntry.SetPlaceHolder("Criterion name")
+			nameEntry.SetText(criterion.Name)
+			nameEntry.OnChanged = func(text string) {
+				oldName := criterion.Name
+				criterion.Name = strings.TrimSpace(text)
+
+				// Keep the juror assignments pointing at the renamed criterion
+				jurorsMutex.Lock()
+				defer jurorsMutex.Unlock()
+				for _, juror := range *jurors {
+					for k, name := range juror.Criteria {
+						if strings.EqualFold(name, oldName) {
+							juror.Criteria[k] = criterion.Name
+						}
+					}
+				}
+			}
+			nameEntry.OnSubmitted = func(string) {
+				rebuildAssignments()
+			}
+
+			weightEntry := widget.NewEntry()
+			weightEntry.SetText(strconv.Itoa(criterion.Weight))
+			weightEntry.OnChanged = func(text string) {
+				if weight, err := strconv.Atoi(strings.TrimSpace(text)); err == nil {
+					criterion.Weight = weight
+					updateCriteriaSum()
+				}
+			}
+
+			removeButton := widget.NewButton("Remove", func() {
+				current.Criteria = append(current.Criteria[:i], current.Criteria[i+1:]...)
+				rebuildCriteria()
+				rebuildAssignments()
+				updateCriteriaSum()
+			})
+
+			criteriaRows.Add(container.NewBorder(nil, nil, nil,
+				container.NewHBox(container.NewGridWrap(fyne.NewSize(70, weightEntry.MinSize().Height), weightEntry), widget.NewLabel("%"), removeButton),
+				nameEntry,
+			))
+		}
+		criteriaRows.Refresh()
+	}
+
+	addButton := widget.NewButton("Add", func() {
+		current.Criteria = append(current.Criteria, &Criterion{})
+		rebuildCriteria()
+		updateCriteriaSum()
+	})
+
+	normalizeButton := widget.NewButton("Normalize", func() {
+		weights := make([]int, len(current.Criteria))
+		for i, criterion := range current.Criteria {
+			weights[i] = criterion.Weight
+		}
+		for i, weight := range normalizeWeights(weights) {
+			current.Criteria[i].Weight = weight
+		}
+		rebuildCriteria()
+		updateCriteriaSum()
+	})
+
+	rebuildCriteria()
+	rebuildAssignments()
+	updateCriteriaSum()
+
+	weightsWindow.SetContent(container.NewBorder(
+		nil,
+		container.NewHBox(
+			layout.NewSpacer(),
+			widget.NewButton("Close", func() {
+				weightsWindow.Close()
+			}),
+		),
+		nil, nil,
+		container.NewVScroll(container.NewVBox(
+			container.NewHBox(
+				widget.NewLabelWithStyle("Criteria:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				layout.NewSpacer(),
+				addButton,
+				normalizeButton,
+			),
+			criteriaRows,
+			criteriaSumText,
+			widget.NewSeparator(),
+			container.NewHBox(
+				widget.NewLabelWithStyle("Criteria scored by each juror:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				layout.NewSpacer(),
+				widget.NewButton("Reload Jurors", func() {
+					rebuildAssignments()
+				}),
+			),
+			assignmentRows,
+		)),
+	))
+	weightsWindow.Show()
+}
+
+// Updates a weight sum indicator and its color
+func updateWeightSumText(sumText *canvas.Text, total int, required bool) {
+	switch {
+	case !required:
+		sumText.Text = "No weights defined."
+		sumText.Color = color.RGBA{R: 128, G: 128, B: 128, A: 255} // Grey
+	case total == 100:
+		sumText.Text = "Total weight: 100%"
+		sumText.Color = color.RGBA{R: 0, G: 128, B: 0, A: 255} // Green
+	default:
+		sumText.Text = fmt.Sprintf("Total weight: %d%% (must be 100%%)", total)
+		sumText.Color = color.RGBA{R: 255, G: 0, B: 0, A: 255} // Red
+	}
+	sumText.Refresh()
+}